@@ -0,0 +1,35 @@
+// Command diceware-server serves diceware passphrases over HTTP. Besides the
+// plain HTTP API of the server package, it serves the typed Service of the
+// service package. Both share the per-client rate limit.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/lukasmalkmus/diceware/server"
+	"github.com/lukasmalkmus/diceware/service"
+)
+
+var (
+	addr  = flag.String("addr", ":8080", "address to listen on")
+	rate  = flag.Float64("rate", server.DefaultRate, "requests per second per client")
+	burst = flag.Int("burst", server.DefaultBurst, "requests at once per client")
+)
+
+func main() {
+	flag.Parse()
+
+	s, err := server.New(server.RateLimit(*rate, *burst))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/passphrase", s)
+	mux.Handle("/diceware.Service/", s.Limit(service.NewHandler(service.New())))
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// A clipboard holds a copied passphrase.
+type clipboard interface {
+	// copy writes the text to the clipboard.
+	copy(text string) error
+
+	// paste reads the text from the clipboard.
+	paste() (string, error)
+
+	// clear empties the clipboard.
+	clear() error
+}
+
+// clipboardTool is a clipboard backed by an external command line tool. The
+// text is passed via stdin, so it doesn't show up in the process list.
+type clipboardTool struct {
+	copyCmd  []string
+	pasteCmd []string
+	clearCmd []string
+}
+
+// clipboardTools are the supported tools in order of preference.
+var clipboardTools = []struct {
+	env  string
+	tool clipboardTool
+}{
+	{"WAYLAND_DISPLAY", clipboardTool{
+		copyCmd:  []string{"wl-copy"},
+		pasteCmd: []string{"wl-paste", "--no-newline"},
+		clearCmd: []string{"wl-copy", "--clear"},
+	}},
+	{"DISPLAY", clipboardTool{
+		copyCmd:  []string{"xclip", "-selection", "clipboard", "-in"},
+		pasteCmd: []string{"xclip", "-selection", "clipboard", "-out"},
+		clearCmd: []string{"xclip", "-selection", "clipboard", "-in"},
+	}},
+	{"DISPLAY", clipboardTool{
+		copyCmd:  []string{"xsel", "--clipboard", "--input"},
+		pasteCmd: []string{"xsel", "--clipboard", "--output"},
+		clearCmd: []string{"xsel", "--clipboard", "--delete"},
+	}},
+}
+
+// detectClipboard returns the first clipboard tool available in the current
+// session. If there is none, the terminal is asked to access the clipboard
+// using the OSC 52 escape sequence.
+func detectClipboard() clipboard {
+	for _, t := range clipboardTools {
+		if os.Getenv(t.env) == "" {
+			continue
+		}
+		if _, err := exec.LookPath(t.tool.copyCmd[0]); err == nil {
+			return t.tool
+		}
+	}
+	return osc52{}
+}
+
+func (t clipboardTool) copy(text string) error {
+	return run(t.copyCmd, text)
+}
+
+func (t clipboardTool) paste() (string, error) {
+	out, err := exec.Command(t.pasteCmd[0], t.pasteCmd[1:]...).Output()
+	return string(out), err
+}
+
+func (t clipboardTool) clear() error {
+	return run(t.clearCmd, "")
+}
+
+// run runs the command with the given stdin. The output isn't captured: tools
+// like xclip fork a process which serves the clipboard and keeps inherited
+// pipes open, so waiting for the output would block until the clipboard is
+// taken over.
+func run(cmd []string, stdin string) error {
+	c := exec.Command(cmd[0], cmd[1:]...)
+	c.Stdin = strings.NewReader(stdin)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("%s: %v", cmd[0], err)
+	}
+	return nil
+}
+
+// clearIfUnchanged clears the clipboard unless something else was copied
+// since the text was copied. If the clipboard can't be read, it is cleared.
+func clearIfUnchanged(c clipboard, text string) error {
+	if current, err := c.paste(); err == nil && current != text {
+		return nil
+	}
+	return c.clear()
+}
+
+// osc52 is a clipboard which is accessed by the terminal emulator using the
+// OSC 52 escape sequence. The clipboard can't be read, so it is always
+// cleared.
+type osc52 struct{}
+
+func (osc52) copy(text string) error {
+	return writeTTY("\033]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a")
+}
+
+func (osc52) paste() (string, error) {
+	return "", errors.New("osc52: clipboard can't be read")
+}
+
+func (osc52) clear() error {
+	// Anything but base64 data clears the clipboard.
+	return writeTTY("\033]52;c;!\a")
+}
+
+func writeTTY(s string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+	_, err = tty.WriteString(s)
+	return err
+}
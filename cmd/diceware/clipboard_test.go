@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeClipboard is an in-memory clipboard.
+type fakeClipboard struct {
+	text     string
+	pasteErr error
+	cleared  bool
+}
+
+func (c *fakeClipboard) copy(text string) error {
+	c.text = text
+	return nil
+}
+
+func (c *fakeClipboard) paste() (string, error) {
+	return c.text, c.pasteErr
+}
+
+func (c *fakeClipboard) clear() error {
+	c.text, c.cleared = "", true
+	return nil
+}
+
+func TestClearIfUnchanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		copied   string
+		pasteErr error
+		cleared  bool
+	}{
+		{"unchanged", "lofty geese borne", nil, true},
+		{"changed", "something else", nil, false},
+		{"unreadable", "something else", errors.New("unreadable"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeClipboard{pasteErr: tt.pasteErr}
+			ok(t, c.copy("lofty geese borne"))
+			ok(t, c.copy(tt.copied))
+			ok(t, clearIfUnchanged(c, "lofty geese borne"))
+			equals(t, tt.cleared, c.cleared)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+// separators are the separators the interactive mode cycles through.
+var separators = []string{" ", "-", ".", "_", ""}
+
+// Keys of the interactive mode.
+const (
+	keyCtrlC  = 3
+	keyEscape = 27
+	keyEnter  = '\r'
+)
+
+const help = "[r]egenerate  [e]xtra  [+/-] words  [s]eparator  [enter] accept  [q]uit"
+
+var errAborted = errors.New("aborted")
+
+// runInteractive lets the user adjust the passphrase in the terminal and
+// returns the accepted passphrase. The user interface is drawn to the terminal
+// directly, so only the accepted passphrase is written to stdout.
+func runInteractive(cfg config) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	restore, err := makeRaw(tty)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	return interact(tty, tty, cfg)
+}
+
+// interact runs the interactive loop reading keys from r and drawing to w.
+func interact(r io.Reader, w io.Writer, cfg config) (string, error) {
+	// Validation is reported instead of enforced, so the user can see the
+	// effect of every setting.
+	options := func() []diceware.Option {
+		return append(cfg.options(), diceware.Validate(false))
+	}
+	p, err := diceware.NewPassphrase(options()...)
+	if err != nil {
+		return "", err
+	}
+
+	key := make([]byte, 1)
+	for {
+		draw(w, cfg, p)
+
+		if _, err := r.Read(key); err != nil {
+			return "", err
+		}
+		switch key[0] {
+		case 'r', ' ':
+			err = p.Regenerate()
+		case 'e':
+			cfg.extra = !cfg.extra
+			p, err = diceware.NewPassphrase(options()...)
+		case '+':
+			cfg.setWords(p.Config().Words + 1)
+			p, err = diceware.NewPassphrase(options()...)
+		case '-':
+			if words := p.Config().Words; words > diceware.MinWords {
+				cfg.setWords(words - 1)
+			}
+			p, err = diceware.NewPassphrase(options()...)
+		case 's':
+			cfg.separator = separators[(indexOf(separators, cfg.separator)+1)%len(separators)]
+		case keyEnter, '\n':
+			fmt.Fprint(w, "\r\033[K\033[1A\033[K\033[1A\033[K")
+			return cfg.format(p), nil
+		case 'q', keyEscape, keyCtrlC:
+			fmt.Fprint(w, "\r\033[K\033[1A\033[K\033[1A\033[K")
+			return "", errAborted
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// draw renders the passphrase, its strength and the help over the previous
+// rendering. The amount of words and the extra are taken from the passphrase,
+// since MinEntropy and length bounds may change them.
+func draw(w io.Writer, cfg config, p *diceware.Passphrase) {
+	warning := ""
+	if !p.Validate() {
+		warning = "  (NOT SAFE)"
+	}
+	fmt.Fprintf(w, "\r\033[K%s\r\n\033[K%d words, extra: %t, %.1f bits, ~%s to crack offline%s\r\n\033[K%s\033[2A\r",
+		cfg.format(p), p.Len(), p.Config().Extra, p.Entropy(), p.CrackTime(diceware.OfflineFastHash), warning, help)
+}
+
+func indexOf(s []string, v string) int {
+	for i := range s {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
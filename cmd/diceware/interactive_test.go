@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestInteract(t *testing.T) {
+	cfg, err := loadConfig("")
+	ok(t, err)
+	cfg.separator = " "
+
+	// Add an extra and a word, then cycle the separator to "_", which neither
+	// appears in the word list nor in the extras.
+	var w bytes.Buffer
+	phrase, err := interact(strings.NewReader("e+sss\r"), &w, cfg)
+	ok(t, err)
+	words := strings.Split(phrase, "_")
+	equals(t, diceware.DefaultWords+1, len(words))
+
+	for _, word := range words {
+		assert(t, inList(word) || inList(word[:len(word)-1]), "Expected %q to be a word of the list.", word)
+	}
+	assert(t, strings.Contains(w.String(), "7 words, extra: true"), "Expected the settings to be drawn, got %q.", w.String())
+
+	// The amount of words chosen by min_entropy is adjusted.
+	entropyCfg := cfg
+	entropyCfg.base.MinEntropy = 90
+	w.Reset()
+	phrase, err = interact(strings.NewReader("+\r"), &w, entropyCfg)
+	ok(t, err)
+	equals(t, 8, len(strings.Fields(phrase)))
+	assert(t, strings.Contains(w.String(), "7 words"), "Expected the words chosen by min_entropy to be drawn, got %q.", w.String())
+
+	_, err = interact(strings.NewReader("rq"), &w, cfg)
+	equals(t, errAborted, err)
+
+	_, err = interact(strings.NewReader("r"), &w, cfg)
+	equals(t, io.EOF, err)
+}
+
+// inList reports whether the word is part of the diceware8k list.
+func inList(word string) bool {
+	for i := 0; i < diceware.Diceware8k.Len(); i++ {
+		if diceware.Diceware8k.Word(i) == word {
+			return true
+		}
+	}
+	return false
+}
+
+// assert fails the test if the condition is false.
+func assert(tb testing.TB, condition bool, msg string, v ...interface{}) {
+	if !condition {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: "+msg+"\033[39m\n\n", append([]interface{}{filepath.Base(file), line}, v...)...)
+		tb.FailNow()
+	}
+}
+
+// ok fails the test if an err is not nil.
+func ok(tb testing.TB, err error) {
+	if err != nil {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: unexpected error: %s\033[39m\n\n", filepath.Base(file), line, err.Error())
+		tb.FailNow()
+	}
+}
+
+// equals fails the test if exp is not equal to act.
+func equals(tb testing.TB, exp, act interface{}) {
+	if !reflect.DeepEqual(exp, act) {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d:\n\n\texp: %#v\n\n\tgot: %#v\033[39m\n\n", filepath.Base(file), line, exp, act)
+		tb.FailNow()
+	}
+}
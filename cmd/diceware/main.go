@@ -0,0 +1,197 @@
+// Command diceware generates diceware passphrases.
+//
+// By default a single passphrase is printed to stdout. In interactive mode
+// (-i), the passphrase can be adjusted in the terminal until it is accepted.
+//
+// With -clip the passphrase is written to the clipboard instead of stdout and
+// cleared after the timeout given by -clear. The tools wl-copy, xclip and xsel
+// are used if present, otherwise the terminal is asked to set the clipboard
+// using the OSC 52 escape sequence.
+//
+// With -sheet text or -sheet html the word list is printed together with the
+// dice rolls selecting each word, e.g. for offline dice ceremonies.
+//
+// With -config the settings are read from a file of "key = value" lines as
+// described by diceware.ParseConfig. Flags which are set explicitly take
+// precedence over the file. An explicit -words replaces min_entropy, while
+// length bounds may still need more words to provide the entropy of -words
+// words.
+//
+// The selftest subcommand generates a large sample of passphrases and tests the
+// words and extras for uniformity, e.g. "diceware -words 8 selftest -samples
+// 100000". It exits with a non-zero status if a test fails.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+var (
+	words       = flag.Int("words", diceware.DefaultWords, "amount of words")
+	extra       = flag.Bool("extra", diceware.DefaultExtra, "add an extra character")
+	checksum    = flag.Bool("checksum", diceware.DefaultChecksum, "append a checksum word")
+	separator   = flag.String("sep", " ", "separator between words")
+	interactive = flag.Bool("i", false, "interactive mode")
+	clip        = flag.Bool("clip", false, "copy to the clipboard instead of printing")
+	clearAfter  = flag.Duration("clear", 45*time.Second, "clear the clipboard after this duration")
+	echo        = flag.Bool("print", false, "print even if copied to the clipboard")
+	sheet       = flag.String("sheet", "", "print the word list as `format` (text or html)")
+	configFile  = flag.String("config", "", "read the settings from `file`")
+)
+
+func main() {
+	flag.Parse()
+
+	if *sheet != "" {
+		if err := writeSheet(*sheet); err != nil {
+			fmt.Fprintf(os.Stderr, "diceware: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diceware: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.separator = *separator
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "words":
+			cfg.setWords(*words)
+		case "extra":
+			cfg.extra = *extra
+		case "checksum":
+			cfg.checksum = *checksum
+		}
+	})
+
+	if flag.Arg(0) == "selftest" {
+		if err := runSelfTest(flag.Args()[1:], cfg, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "diceware: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var phrase string
+	if *interactive {
+		phrase, err = runInteractive(cfg)
+	} else {
+		phrase, err = generate(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "diceware: %v\n", err)
+		os.Exit(1)
+	}
+	if phrase == "" {
+		return
+	}
+
+	if !*clip || *echo {
+		fmt.Println(phrase)
+	}
+	if *clip {
+		if err := copyAndClear(detectClipboard(), phrase, *clearAfter); err != nil {
+			fmt.Fprintf(os.Stderr, "diceware: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeSheet writes the word list in the given format to stdout.
+func writeSheet(format string) error {
+	switch format {
+	case "text":
+		return diceware.WriteTextSheet(os.Stdout, diceware.Diceware8k, 4)
+	case "html":
+		return diceware.WriteHTMLSheet(os.Stdout, diceware.Diceware8k)
+	}
+	return fmt.Errorf("unknown sheet format %q", format)
+}
+
+// copyAndClear copies the passphrase to the clipboard and waits for the
+// timeout or an interrupt before it clears the clipboard again.
+func copyAndClear(c clipboard, phrase string, timeout time.Duration) error {
+	if err := c.copy(phrase); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Copied to clipboard. Clearing in %s.\n", timeout)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	select {
+	case <-time.After(timeout):
+	case <-sig:
+	}
+	return clearIfUnchanged(c, phrase)
+}
+
+// config holds the settings of the generated passphrase. The settings which
+// can't be changed by flags are taken from base.
+type config struct {
+	base      diceware.Config
+	words     int
+	extra     bool
+	checksum  bool
+	separator string
+}
+
+// loadConfig reads the configuration file with the given name. If name is
+// empty, the default configuration is returned.
+func loadConfig(name string) (config, error) {
+	base := diceware.DefaultConfig()
+	if name != "" {
+		f, err := os.Open(name)
+		if err != nil {
+			return config{}, err
+		}
+		defer f.Close()
+		if base, err = diceware.ParseConfig(f); err != nil {
+			return config{}, err
+		}
+	}
+	return config{
+		base:     base,
+		words:    base.Words,
+		extra:    base.Extra,
+		checksum: base.Checksum,
+	}, nil
+}
+
+// setWords sets the amount of words. The min_entropy of the configuration file
+// is cleared, since it would choose the amount of words instead.
+func (c *config) setWords(words int) {
+	c.words = words
+	c.base.MinEntropy = 0
+}
+
+func (c config) options() []diceware.Option {
+	cfg := c.base
+	cfg.Words, cfg.Extra, cfg.Checksum = c.words, c.extra, c.checksum
+	return cfg.Options()
+}
+
+// format joins the words of the passphrase with the configured separator.
+func (c config) format(p *diceware.Passphrase) string {
+	return strings.Join(p.Words(), c.separator)
+}
+
+func generate(cfg config) (string, error) {
+	p, err := diceware.NewPassphrase(cfg.options()...)
+	if err != nil {
+		return "", err
+	}
+	return cfg.format(p), nil
+}
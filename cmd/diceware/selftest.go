@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+// errSelfTestFailed is returned if a distribution isn't considered uniform.
+var errSelfTestFailed = errors.New("self-test failed")
+
+// runSelfTest runs the statistical self-test of the passphrase generation with
+// the given configuration and writes the results to w.
+func runSelfTest(args []string, cfg config, w io.Writer) error {
+	fs := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	samples := fs.Int("samples", 100000, "amount of passphrases to generate")
+	alpha := fs.Float64("alpha", 0.001, "significance level")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := diceware.SelfTest(*samples, cfg.options()...)
+	if err != nil {
+		return err
+	}
+	failed := false
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed(*alpha) {
+			status, failed = "FAIL", true
+		}
+		fmt.Fprintf(w, "%s %s\n", status, r)
+	}
+	if failed {
+		return errSelfTestFailed
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// makeRaw is only implemented for linux.
+func makeRaw(tty *os.File) (func(), error) {
+	return nil, errors.New("interactive mode is not supported on this platform")
+}
@@ -0,0 +1,273 @@
+package diceware
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConfig is raised when a configuration can't be parsed.
+var ErrInvalidConfig = errors.New("diceware: configuration is invalid")
+
+// A Config holds the settings a passphrase is generated with. It can be read
+// from a Passphrase using Passphrase.Config and turned back into Options using
+// Config.Options, so the settings can be stored, audited and reproduced.
+//
+// The zero values of MinEditDistance, MinLength, MaxLength and MinEntropy
+// leave the corresponding Option unset. Since Extra is always set, MinEntropy
+// only adds an extra if Extra is true. Use DefaultConfig as a starting point
+// for custom configurations.
+//
+// List and ListFingerprint hold the name and the fingerprint of the word list.
+// They are empty for the Diceware8k list. Options looks the name up using
+// LookupList. Lists read by ReadWordList have to be passed with the List
+// Option after the Options of the configuration. In both cases, the
+// fingerprint of the list must match ListFingerprint, otherwise
+// ErrFingerprintMismatch is returned.
+//
+// Derivation holds the parameters of a passphrase created by Derive as
+// returned by Passphrase.Derivation. It is empty for random passphrases. Use
+// Config.Derive to reproduce a derived passphrase.
+type Config struct {
+	Words           int     `json:"words"`
+	Extra           bool    `json:"extra"`
+	Checksum        bool    `json:"checksum"`
+	Unique          bool    `json:"unique"`
+	Validate        bool    `json:"validate"`
+	MinEditDistance int     `json:"min_edit_distance,omitempty"`
+	DistinctSounds  bool    `json:"distinct_sounds"`
+	MinLength       int     `json:"min_length,omitempty"`
+	MaxLength       int     `json:"max_length,omitempty"`
+	MinEntropy      float64 `json:"min_entropy,omitempty"`
+	List            string  `json:"list,omitempty"`
+	ListFingerprint string  `json:"list_fingerprint,omitempty"`
+	Derivation      string  `json:"derivation,omitempty"`
+}
+
+// DefaultConfig returns the configuration used if no Options are supplied.
+func DefaultConfig() Config {
+	return Config{
+		Words:           DefaultWords,
+		Extra:           DefaultExtra,
+		Checksum:        DefaultChecksum,
+		Unique:          DefaultUnique,
+		Validate:        DefaultValidate,
+		MinEditDistance: DefaultMinEditDistance,
+		DistinctSounds:  DefaultDistinctSounds,
+	}
+}
+
+// Config returns the configuration of the passphrase. If the MinEntropy Option
+// is set, Words and Extra hold the values chosen to reach the entropy, also
+// within length bounds. Otherwise Words holds the value of the Words Option,
+// which sets the entropy of length-bounded passphrases.
+func (p Passphrase) Config() Config {
+	words := p.wordCount
+	if p.minEntropy > 0 {
+		words = p.wordsPicked()
+	}
+	var list, listFingerprint string
+	if p.list != Diceware8k {
+		list, listFingerprint = p.list.Name(), p.list.Fingerprint()
+	}
+	return Config{
+		Words:           words,
+		Extra:           p.extra,
+		Checksum:        p.checksum,
+		Unique:          p.unique,
+		Validate:        p.validate,
+		MinEditDistance: p.minEditDistance,
+		DistinctSounds:  p.distinctSounds,
+		MinLength:       p.minLength,
+		MaxLength:       p.maxLength,
+		MinEntropy:      p.minEntropy,
+		List:            list,
+		ListFingerprint: listFingerprint,
+		Derivation:      p.Derivation(),
+	}
+}
+
+// Options returns the Options which apply the configuration.
+func (c Config) Options() []Option {
+	options := []Option{
+		Words(c.Words),
+		Extra(c.Extra),
+		Checksum(c.Checksum),
+		Unique(c.Unique),
+		Validate(c.Validate),
+		DistinctSounds(c.DistinctSounds),
+	}
+	if c.MinEditDistance != 0 {
+		options = append(options, MinEditDistance(c.MinEditDistance))
+	}
+	if c.MinLength != 0 {
+		options = append(options, MinLength(c.MinLength))
+	}
+	if c.MaxLength != 0 {
+		options = append(options, MaxLength(c.MaxLength))
+	}
+	if c.MinEntropy != 0 {
+		options = append(options, MinEntropy(c.MinEntropy))
+	}
+	if c.List != "" || c.ListFingerprint != "" {
+		options = append(options, configList(c.List, c.ListFingerprint))
+	}
+	return options
+}
+
+// configList is an Option that selects the embedded word list with the given
+// name, if there is one, and requires the fingerprint of the finally selected
+// list to match the given one. Lists which are not embedded are selected by a
+// List Option which follows.
+func configList(name, fingerprint string) Option {
+	return func(p *Passphrase) error {
+		if l := LookupList(name); l != nil {
+			p.list = l
+		}
+		p.listFingerprint = fingerprint
+		return nil
+	}
+}
+
+// Derive derives the passphrase described by the configuration from the given
+// seed, using the context, counter and stretching of Derivation. An error
+// matching ErrInvalidConfig is returned if Derivation is empty or malformed.
+func (c Config) Derive(seed []byte) (*Passphrase, error) {
+	if c.Derivation == "" {
+		return nil, fmt.Errorf("%w: missing derivation", ErrInvalidConfig)
+	}
+	d, err := parseDerivation(c.Derivation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	options := append(c.Options(), Counter(d.counter))
+	if d.iterations > 0 {
+		options = append(options, Stretch(d.iterations))
+	}
+	return Derive(seed, d.context, options...)
+}
+
+// String returns the configuration in the format read by ParseConfig. Unset
+// Options are omitted.
+func (c Config) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "words = %d\n", c.Words)
+	fmt.Fprintf(&b, "extra = %t\n", c.Extra)
+	fmt.Fprintf(&b, "checksum = %t\n", c.Checksum)
+	fmt.Fprintf(&b, "unique = %t\n", c.Unique)
+	fmt.Fprintf(&b, "validate = %t\n", c.Validate)
+	if c.MinEditDistance != 0 {
+		fmt.Fprintf(&b, "min_edit_distance = %d\n", c.MinEditDistance)
+	}
+	fmt.Fprintf(&b, "distinct_sounds = %t\n", c.DistinctSounds)
+	if c.MinLength != 0 {
+		fmt.Fprintf(&b, "min_length = %d\n", c.MinLength)
+	}
+	if c.MaxLength != 0 {
+		fmt.Fprintf(&b, "max_length = %d\n", c.MaxLength)
+	}
+	if c.MinEntropy != 0 {
+		fmt.Fprintf(&b, "min_entropy = %s\n", strconv.FormatFloat(c.MinEntropy, 'g', -1, 64))
+	}
+	if c.List != "" {
+		fmt.Fprintf(&b, "list = %s\n", c.List)
+	}
+	if c.ListFingerprint != "" {
+		fmt.Fprintf(&b, "list_fingerprint = %s\n", c.ListFingerprint)
+	}
+	if c.Derivation != "" {
+		fmt.Fprintf(&b, "derivation = %s\n", c.Derivation)
+	}
+	return b.String()
+}
+
+// ParseConfig reads a configuration of "key = value" lines, e.g.
+//
+//	# Credentials of the admin accounts.
+//	words = 8
+//	extra = true
+//
+// The keys are the JSON names of the Config fields. Empty lines and lines
+// starting with "#" are ignored and values may be enclosed in quotes. Keys
+// which are missing keep the value of DefaultConfig. An error matching
+// ErrInvalidConfig is returned for unknown keys, repeated keys and invalid
+// values.
+func ParseConfig(r io.Reader) (Config, error) {
+	c := DefaultConfig()
+	seen := make(map[string]bool)
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		i := strings.IndexByte(text, '=')
+		if i < 0 {
+			return Config{}, fmt.Errorf("%w: line %d: missing \"=\"", ErrInvalidConfig, line)
+		}
+		key := strings.TrimSpace(text[:i])
+		value := strings.TrimSpace(text[i+1:])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		if seen[key] {
+			return Config{}, fmt.Errorf("%w: line %d: repeated key %q", ErrInvalidConfig, line, key)
+		}
+		seen[key] = true
+		if err := c.set(key, value); err != nil {
+			return Config{}, fmt.Errorf("%w: line %d: %v", ErrInvalidConfig, line, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// set assigns the value to the field of the configuration with the given key.
+func (c *Config) set(key, value string) error {
+	var err error
+	switch key {
+	case "words":
+		c.Words, err = strconv.Atoi(value)
+	case "extra":
+		c.Extra, err = strconv.ParseBool(value)
+	case "checksum":
+		c.Checksum, err = strconv.ParseBool(value)
+	case "unique":
+		c.Unique, err = strconv.ParseBool(value)
+	case "validate":
+		c.Validate, err = strconv.ParseBool(value)
+	case "min_edit_distance":
+		c.MinEditDistance, err = strconv.Atoi(value)
+	case "distinct_sounds":
+		c.DistinctSounds, err = strconv.ParseBool(value)
+	case "min_length":
+		c.MinLength, err = strconv.Atoi(value)
+	case "max_length":
+		c.MaxLength, err = strconv.Atoi(value)
+	case "min_entropy":
+		c.MinEntropy, err = strconv.ParseFloat(value, 64)
+	case "list":
+		c.List = value
+	case "list_fingerprint":
+		if b, decErr := hex.DecodeString(value); decErr != nil || len(b) != sha256.Size {
+			return fmt.Errorf("invalid value %q for key %q", value, key)
+		}
+		c.ListFingerprint = strings.ToLower(value)
+	case "derivation":
+		_, err = parseDerivation(value)
+		c.Derivation = value
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for key %q", value, key)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package diceware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPassphrase_Config(t *testing.T) {
+	// Validation is skipped, since a few default passphrases are too short.
+	phrase, err := diceware.NewPassphrase(diceware.Validate(false))
+	ok(t, err)
+	expected := diceware.DefaultConfig()
+	expected.Validate = false
+	equals(t, expected, phrase.Config())
+
+	tests := [][]diceware.Option{
+		{diceware.Words(8), diceware.Extra(true), diceware.Checksum(true)},
+		{diceware.Words(3), diceware.Validate(false), diceware.Unique(true)},
+		{diceware.MinEditDistance(2), diceware.DistinctSounds(true)},
+		{diceware.MinLength(20), diceware.MaxLength(30)},
+		{diceware.MinEntropy(80)},
+		{diceware.MinEntropy(80), diceware.Extra(false)},
+	}
+
+	for _, options := range tests {
+		phrase, err := diceware.NewPassphrase(append(options, diceware.Validate(false))...)
+		ok(t, err)
+		config := phrase.Config()
+
+		// The configuration reproduces the passphrase settings.
+		reproduced, err := diceware.NewPassphrase(config.Options()...)
+		ok(t, err)
+		equals(t, config, reproduced.Config())
+		equals(t, phrase.Entropy(), reproduced.Entropy())
+
+		// The configuration survives the file format and JSON.
+		parsed, err := diceware.ParseConfig(strings.NewReader(config.String()))
+		ok(t, err)
+		equals(t, config, parsed)
+
+		b, err := json.Marshal(config)
+		ok(t, err)
+		var decoded diceware.Config
+		ok(t, json.Unmarshal(b, &decoded))
+		equals(t, config, decoded)
+	}
+}
+
+func TestConfig_Derive(t *testing.T) {
+	phrase, err := diceware.Derive(seed, "example.com", diceware.Counter(3), diceware.Words(8))
+	ok(t, err)
+	config := phrase.Config()
+	equals(t, `hkdf-sha256;counter=3;context="example.com"`, config.Derivation)
+
+	// The configuration survives the file format and reproduces the
+	// passphrase.
+	parsed, err := diceware.ParseConfig(strings.NewReader(config.String()))
+	ok(t, err)
+	equals(t, config, parsed)
+	derived, err := parsed.Derive(seed)
+	ok(t, err)
+	equals(t, phrase.String(), derived.String())
+
+	// The stretching is reproduced as well.
+	phrase, err = diceware.Derive(seed, "example.com", diceware.Stretch(1000))
+	ok(t, err)
+	parsed, err = diceware.ParseConfig(strings.NewReader(phrase.Config().String()))
+	ok(t, err)
+	derived, err = parsed.Derive(seed)
+	ok(t, err)
+	equals(t, phrase.String(), derived.String())
+	equals(t, phrase.Derivation(), derived.Derivation())
+
+	_, err = diceware.DefaultConfig().Derive(seed)
+	assert(t, errors.Is(err, diceware.ErrInvalidConfig), "Expected invalid config, got %v.", err)
+}
+
+func TestConfig_List(t *testing.T) {
+	// A custom list which reuses the name of an embedded list isn't
+	// replaced by the embedded list.
+	l, err := diceware.ReadWordList("diceware8k", strings.NewReader("alpha\nbravo\ncharlie\ndelta"))
+	ok(t, err)
+	phrase, err := diceware.NewPassphrase(diceware.List(l), diceware.Validate(false))
+	ok(t, err)
+	config := phrase.Config()
+	equals(t, "diceware8k", config.List)
+	equals(t, l.Fingerprint(), config.ListFingerprint)
+
+	parsed, err := diceware.ParseConfig(strings.NewReader(config.String()))
+	ok(t, err)
+	equals(t, config, parsed)
+
+	_, err = diceware.NewPassphrase(parsed.Options()...)
+	equals(t, diceware.ErrFingerprintMismatch, err)
+
+	reproduced, err := diceware.NewPassphrase(append(parsed.Options(), diceware.List(l))...)
+	ok(t, err)
+	equals(t, config, reproduced.Config())
+}
+
+func TestParseConfig(t *testing.T) {
+	config, err := diceware.ParseConfig(strings.NewReader(`
+# Credentials of the admin accounts.
+words = 8
+extra = "true"
+min_entropy=100.5
+`))
+	ok(t, err)
+	expected := diceware.DefaultConfig()
+	expected.Words = 8
+	expected.Extra = true
+	expected.MinEntropy = 100.5
+	equals(t, expected, config)
+
+	tests := []string{
+		"words",
+		"words = eight",
+		"extra = maybe",
+		"colour = blue",
+		"words = 8\nwords = 9",
+		"derivation = hkdf-sha256;counter=x;context=\"example.com\"",
+		"derivation = md5;counter=0;context=\"example.com\"",
+		"derivation = hkdf-sha256;pbkdf2=0;counter=0;context=\"example.com\"",
+		"list_fingerprint = 2f350b",
+	}
+	for _, tt := range tests {
+		_, err := diceware.ParseConfig(strings.NewReader(tt))
+		assert(t, errors.Is(err, diceware.ErrInvalidConfig), "Expected invalid config for %q, got %v.", tt, err)
+	}
+}
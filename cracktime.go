@@ -0,0 +1,126 @@
+package diceware
+
+import (
+	"fmt"
+	"math"
+)
+
+// An AttackerModel describes the capabilities of an attacker trying to crack a
+// passphrase by guessing.
+type AttackerModel struct {
+	// Name describes the attacker.
+	Name string
+
+	// GuessesPerSecond is the rate at which the attacker can guess.
+	GuessesPerSecond float64
+}
+
+var (
+	// OnlineThrottled models an attacker guessing against an online service
+	// which limits the rate of login attempts.
+	OnlineThrottled = AttackerModel{"online, throttled", 100.0 / 3600}
+
+	// OnlineUnthrottled models an attacker guessing against an online service
+	// which doesn't limit the rate of login attempts.
+	OnlineUnthrottled = AttackerModel{"online, unthrottled", 10}
+
+	// OfflineSlowHash models an attacker who stole a database of passwords
+	// hashed with a slow hash function like bcrypt, scrypt or Argon2.
+	OfflineSlowHash = AttackerModel{"offline, slow hash", 1e4}
+
+	// OfflineFastHash models an attacker who stole a database of passwords
+	// hashed with a fast hash function like MD5 and guesses with many GPUs.
+	OfflineFastHash = AttackerModel{"offline, fast hash", 1e10}
+
+	// AttackerModels are all predefined attacker models.
+	AttackerModels = []AttackerModel{
+		OnlineThrottled,
+		OnlineUnthrottled,
+		OfflineSlowHash,
+		OfflineFastHash,
+	}
+)
+
+// CrackTime is an estimated time to crack a passphrase in seconds. Unlike
+// time.Duration, it doesn't overflow for strong passphrases.
+type CrackTime float64
+
+// Time units used to format a CrackTime.
+const (
+	minute  = 60
+	hour    = 60 * minute
+	day     = 24 * hour
+	month   = 31 * day
+	year    = 12 * month
+	century = 100 * year
+)
+
+// String returns a human friendly representation of the crack time, e.g.
+// "3 hours" or "12 million centuries".
+func (t CrackTime) String() string {
+	s := float64(t)
+	switch {
+	case s < 1:
+		return "less than a second"
+	case s < minute:
+		return plural(s, "second")
+	case s < hour:
+		return plural(s/minute, "minute")
+	case s < day:
+		return plural(s/hour, "hour")
+	case s < month:
+		return plural(s/day, "day")
+	case s < year:
+		return plural(s/month, "month")
+	case s < century:
+		return plural(s/year, "year")
+	}
+
+	c := s / century
+	if c >= 1e15 {
+		return "more than a quadrillion centuries"
+	}
+	for _, scale := range []struct {
+		name  string
+		value float64
+	}{
+		{"trillion", 1e12},
+		{"billion", 1e9},
+		{"million", 1e6},
+	} {
+		if c >= scale.value {
+			return fmt.Sprintf("%.0f %s centuries", math.Floor(c/scale.value), scale.name)
+		}
+	}
+	return plural(c, "century")
+}
+
+func plural(n float64, unit string) string {
+	n = math.Floor(n)
+	if n == 1 {
+		return "1 " + unit
+	}
+	if unit == "century" {
+		return fmt.Sprintf("%.0f centuries", n)
+	}
+	return fmt.Sprintf("%.0f %ss", n, unit)
+}
+
+// crackTime returns the average time it takes the attacker to find a secret
+// among the given amount of guesses: half of the guesses.
+func crackTime(guesses float64, a AttackerModel) CrackTime {
+	return CrackTime(guesses / 2 / a.GuessesPerSecond)
+}
+
+// CrackTime returns the average time it takes the given attacker to crack the
+// passphrase, assuming the attacker knows how it was generated. It is based on
+// the Entropy of the passphrase.
+func (p Passphrase) CrackTime(a AttackerModel) CrackTime {
+	return crackTime(math.Pow(2, p.Entropy()), a)
+}
+
+// CrackTime returns the average time it takes the given attacker to crack the
+// password. It is based on the estimated Guesses.
+func (s Strength) CrackTime(a AttackerModel) CrackTime {
+	return crackTime(s.Guesses, a)
+}
@@ -0,0 +1,49 @@
+package diceware_test
+
+import (
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestCrackTime_String(t *testing.T) {
+	tests := []struct {
+		seconds  float64
+		expected string
+	}{
+		{0.5, "less than a second"},
+		{1, "1 second"},
+		{59, "59 seconds"},
+		{90, "1 minute"},
+		{3 * 3600, "3 hours"},
+		{2 * 86400, "2 days"},
+		{5 * 31 * 86400, "5 months"},
+		{7 * 372 * 86400, "7 years"},
+		{37200 * 86400, "1 century"},
+		{3 * 37200 * 86400, "3 centuries"},
+		{12e6 * 37200 * 86400, "12 million centuries"},
+		{1e20 * 37200 * 86400, "more than a quadrillion centuries"},
+	}
+
+	for _, tt := range tests {
+		equals(t, tt.expected, diceware.CrackTime(tt.seconds).String())
+	}
+}
+
+func TestPassphrase_CrackTime(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(diceware.Validate(false))
+	ok(t, err)
+
+	// 2^78 / 2 / 1e10 seconds.
+	equals(t, "4701 centuries", phrase.CrackTime(diceware.OfflineFastHash).String())
+
+	prev := diceware.CrackTime(0)
+	for i := len(diceware.AttackerModels) - 1; i >= 0; i-- {
+		ct := phrase.CrackTime(diceware.AttackerModels[i])
+		assert(t, ct > prev, "Expected slower attacker %q to need more time.", diceware.AttackerModels[i].Name)
+		prev = ct
+	}
+
+	s := diceware.Estimate("qwerty")
+	equals(t, "less than a second", s.CrackTime(diceware.OfflineFastHash).String())
+}
@@ -0,0 +1,240 @@
+package diceware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DerivationAlgorithm identifies the key derivation function used by
+	// Derive. It is HKDF (RFC 5869) instantiated with SHA-256.
+	DerivationAlgorithm = "hkdf-sha256"
+
+	// MinSeedLength is the smallest amount of bytes accepted as seed by Derive.
+	// It only suits uniformly random seeds, see Derive.
+	MinSeedLength = 16
+)
+
+// derivationSalt is the fixed HKDF salt which separates diceware derivations
+// from other uses of the same seed.
+var derivationSalt = []byte("github.com/lukasmalkmus/diceware")
+
+var (
+	// ErrInvalidSeed is raised when the seed passed to Derive is shorter than
+	// MinSeedLength.
+	ErrInvalidSeed = errors.New("diceware: seed is too short")
+
+	// ErrInvalidIterations is raised when the amount of iterations passed to
+	// Stretch is smaller than one or larger than MaxIterations.
+	ErrInvalidIterations = errors.New("diceware: amount of iterations is invalid")
+)
+
+// Counter is an Option that sets the counter used by Derive. Incrementing the
+// counter derives a new passphrase for the same seed and context, e.g. after a
+// credential has been rotated. It has no effect on randomly generated
+// passphrases.
+func Counter(counter uint32) Option {
+	return func(p *Passphrase) error { return p.setCounter(counter) }
+}
+func (p *Passphrase) setCounter(counter uint32) error {
+	if p.derivation == nil {
+		p.derivation = &derivation{}
+	}
+	p.derivation.counter = counter
+	return nil
+}
+
+// Stretch is an Option that stretches the seed passed to Derive using
+// PBKDF2-SHA256 with the given amount of iterations, e.g. DefaultIterations,
+// before the key derivation. This makes guessing a seed which isn't uniformly
+// random, like a master password, expensive. The iterations are part of the
+// Derivation. It has no effect on randomly generated passphrases.
+func Stretch(iterations int) Option {
+	return func(p *Passphrase) error { return p.setStretch(iterations) }
+}
+func (p *Passphrase) setStretch(iterations int) error {
+	if iterations < 1 || iterations > MaxIterations {
+		return ErrInvalidIterations
+	}
+	if p.derivation == nil {
+		p.derivation = &derivation{}
+	}
+	p.derivation.iterations = iterations
+	return nil
+}
+
+// derivation holds the parameters of a derived passphrase.
+type derivation struct {
+	context    string
+	counter    uint32
+	iterations int
+}
+
+// String encodes the derivation parameters. The PBKDF2 iterations are only
+// included if the seed is stretched.
+func (d derivation) String() string {
+	stretch := ""
+	if d.iterations > 0 {
+		stretch = fmt.Sprintf("pbkdf2=%d;", d.iterations)
+	}
+	return fmt.Sprintf("%s;%scounter=%d;context=%q", DerivationAlgorithm, stretch, d.counter, d.context)
+}
+
+// parseDerivation parses the derivation parameters encoded by String.
+func parseDerivation(s string) (derivation, error) {
+	var d derivation
+	parts := strings.SplitN(s, ";", 3)
+	if len(parts) == 3 && strings.HasPrefix(parts[1], "pbkdf2=") {
+		iterations, err := parseIterations("i=" + strings.TrimPrefix(parts[1], "pbkdf2="))
+		if err != nil {
+			return d, fmt.Errorf("invalid derivation iterations %q", parts[1])
+		}
+		d.iterations = iterations
+		parts = append(parts[:1], strings.SplitN(parts[2], ";", 2)...)
+	}
+	if len(parts) != 3 || parts[0] != DerivationAlgorithm ||
+		!strings.HasPrefix(parts[1], "counter=") || !strings.HasPrefix(parts[2], "context=") {
+		return d, fmt.Errorf("invalid derivation %q", s)
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(parts[1], "counter="), 10, 32)
+	if err != nil {
+		return d, fmt.Errorf("invalid derivation counter %q", parts[1])
+	}
+	d.counter = uint32(n)
+	if d.context, err = strconv.Unquote(strings.TrimPrefix(parts[2], "context=")); err != nil {
+		return d, fmt.Errorf("invalid derivation context %q", parts[2])
+	}
+	return d, nil
+}
+
+// Derive deterministically derives a passphrase from the given seed and
+// context. The same seed, context, counter and options always yield the same
+// passphrase which makes it possible to regenerate credentials without storing
+// them.
+//
+// The seed must be uniformly random key material, e.g. 32 bytes read from
+// crypto/rand, since HKDF doesn't make guessing the seed any harder. Seeds
+// which can be guessed, like passwords, must be stretched using the Stretch
+// Option.
+//
+// The seed is used as input keying material for HKDF-SHA256. If the Stretch
+// Option is set, the output of PBKDF2-SHA256 with the same salt is used
+// instead. The info
+// parameter is the context followed by the counter as 4 byte big endian
+// integer. The output key material is used as source for the word selection.
+// Calling Regenerate on a derived passphrase continues to read from that
+// source and therefore yields a different, but still reproducible passphrase.
+// The same applies to passphrases which fail validation because of their
+// length: they are skipped. Other validation errors, like too few words, are
+// returned right away.
+func Derive(seed []byte, context string, options ...Option) (*Passphrase, error) {
+	if len(seed) < MinSeedLength {
+		return nil, ErrInvalidSeed
+	}
+
+	p, err := newPassphrase(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup the key stream.
+	if p.derivation == nil {
+		p.derivation = &derivation{}
+	}
+	p.derivation.context = context
+	info := make([]byte, len(context)+4)
+	copy(info, context)
+	binary.BigEndian.PutUint32(info[len(context):], p.derivation.counter)
+	if p.derivation.iterations > 0 {
+		seed = pbkdf2(seed, derivationSalt, p.derivation.iterations, sha256.Size)
+	}
+	p.source = newHKDF(seed, derivationSalt, info)
+
+	// Generate passphrase. Passphrases which fail validation because of their
+	// length are skipped so the result stays deterministic.
+	err = p.Regenerate()
+	for skippable(err) {
+		err = p.Regenerate()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Return passphrase.
+	return p, nil
+}
+
+// skippable reports whether the error is a validation error which only
+// violates length rules. Another passphrase may meet these rules, while the
+// other rules fail for every passphrase of the same options.
+func skippable(err error) bool {
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) {
+		return false
+	}
+	for _, v := range vErr.Rules {
+		if v.Rule != RuleMinLength && v.Rule != RuleMaxLength {
+			return false
+		}
+	}
+	return true
+}
+
+// Derivation returns the encoded key derivation parameters of a passphrase
+// created by Derive, e.g. `hkdf-sha256;counter=0;context="example.com"`. It
+// returns an empty string for randomly generated passphrases.
+func (p Passphrase) Derivation() string {
+	if _, ok := p.source.(*hkdf); !ok {
+		return ""
+	}
+	return p.derivation.String()
+}
+
+// hkdf implements the HKDF-Expand step of RFC 5869 as io.Reader. The amount of
+// output is limited to 255 blocks of the underlying hash.
+type hkdf struct {
+	expander hash.Hash
+	info     []byte
+	prev     []byte
+	buf      []byte
+	counter  byte
+}
+
+func newHKDF(secret, salt, info []byte) *hkdf {
+	extractor := hmac.New(sha256.New, salt)
+	extractor.Write(secret)
+	return &hkdf{
+		expander: hmac.New(sha256.New, extractor.Sum(nil)),
+		info:     info,
+	}
+}
+
+// Read implements the io.Reader interface.
+func (h *hkdf) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(h.buf) == 0 {
+			if h.counter == 255 {
+				return n, io.ErrUnexpectedEOF
+			}
+			h.counter++
+			h.expander.Reset()
+			h.expander.Write(h.prev)
+			h.expander.Write(h.info)
+			h.expander.Write([]byte{h.counter})
+			h.prev = h.expander.Sum(nil)
+			h.buf = h.prev
+		}
+		c := copy(p[n:], h.buf)
+		h.buf = h.buf[c:]
+		n += c
+	}
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package diceware_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+var seed = []byte("0123456789abcdef0123456789abcdef")
+
+func TestDerive(t *testing.T) {
+	phrase1, err := diceware.Derive(seed, "example.com", diceware.Extra(true))
+	ok(t, err)
+	phrase2, err := diceware.Derive(seed, "example.com", diceware.Extra(true))
+	ok(t, err)
+	equals(t, phrase1.String(), phrase2.String())
+	equals(t, `hkdf-sha256;counter=0;context="example.com"`, phrase1.Derivation())
+
+	phrase3, err := diceware.Derive(seed, "example.org", diceware.Extra(true))
+	ok(t, err)
+	assert(t, phrase1.String() != phrase3.String(), "Expected different context to derive a different passphrase.")
+
+	phrase4, err := diceware.Derive(seed, "example.com", diceware.Extra(true), diceware.Counter(1))
+	ok(t, err)
+	assert(t, phrase1.String() != phrase4.String(), "Expected different counter to derive a different passphrase.")
+	equals(t, `hkdf-sha256;counter=1;context="example.com"`, phrase4.Derivation())
+
+	// The derivation must never change for existing seeds.
+	phrase6, err := diceware.Derive(seed, "example.com")
+	ok(t, err)
+	equals(t, "lofty geese borne loess covet ff", phrase6.Humanize())
+
+	_, err = diceware.Derive(seed[:diceware.MinSeedLength-1], "example.com")
+	equals(t, diceware.ErrInvalidSeed, err)
+
+	// A stretched seed derives a different passphrase.
+	phrase7, err := diceware.Derive(seed, "example.com", diceware.Stretch(1000))
+	ok(t, err)
+	assert(t, phrase6.String() != phrase7.String(), "Expected a stretched seed to derive a different passphrase.")
+	equals(t, `hkdf-sha256;pbkdf2=1000;counter=0;context="example.com"`, phrase7.Derivation())
+
+	for _, iterations := range []int{0, diceware.MaxIterations + 1} {
+		_, err = diceware.Derive(seed, "example.com", diceware.Stretch(iterations))
+		equals(t, diceware.ErrInvalidIterations, err)
+	}
+
+	// Too few words fail validation for every passphrase.
+	_, err = diceware.Derive(seed, "example.com", diceware.Words(3))
+	var vErr *diceware.ValidationError
+	assert(t, errors.As(err, &vErr), "Expected *ValidationError, got %v.", err)
+
+	phrase5, err := diceware.NewPassphrase(diceware.Counter(1), diceware.Validate(false))
+	ok(t, err)
+	equals(t, "", phrase5.Derivation())
+}
@@ -0,0 +1,114 @@
+package diceware
+
+import (
+	"errors"
+	"math/bits"
+	"strings"
+)
+
+var (
+	// ErrInvalidIndex is raised when an index is out of the range of a word
+	// list or dice code.
+	ErrInvalidIndex = errors.New("diceware: index is out of range")
+
+	// ErrInvalidDiceCode is raised when a dice code is malformed or doesn't
+	// select a word of the list.
+	ErrInvalidDiceCode = errors.New("diceware: dice code is invalid")
+)
+
+// maxBits is the maximum amount of bits a dice code can select.
+const maxBits = bits.UintSize - 2
+
+// DiceCode returns the rolls of the given amount of six-sided dice which select
+// the index i of a list with 6^dice words, e.g. "11111" to "66666" for the
+// original 7776 word list and 5 dice. Every digit is the result of a die,
+// starting with the most significant one. ErrInvalidIndex is returned if i is
+// out of range.
+func DiceCode(i, dice int) (string, error) {
+	n, ok := power(6, dice)
+	if !ok || i < 0 || i >= n {
+		return "", ErrInvalidIndex
+	}
+	return digits(i, n, 6, ""), nil
+}
+
+// DiceIndex returns the index selected by the rolls of six-sided dice as
+// returned by DiceCode. Every die is a digit from 1 to 6. ErrInvalidDiceCode
+// is returned if the code contains other characters.
+func DiceIndex(code string) (int, error) {
+	return parseDigits(code, 6)
+}
+
+// CoinCode returns the coin flips which select the index i of a list with
+// 2^flips words. Every flip selects a bit, starting with the most significant
+// one: heads ("H") is a 0, tails ("T") is a 1. ErrInvalidIndex is returned if
+// i is out of range.
+func CoinCode(i, flips int) (string, error) {
+	if flips < 1 || flips > maxBits || i < 0 || i >= 1<<uint(flips) {
+		return "", ErrInvalidIndex
+	}
+	code := make([]byte, flips)
+	for j := range code {
+		code[j] = 'H'
+		if i>>uint(flips-1-j)&1 == 1 {
+			code[j] = 'T'
+		}
+	}
+	return string(code), nil
+}
+
+// CoinIndex returns the index selected by the coin flips as returned by
+// CoinCode. Binary digits ("0" and "1") are accepted as well.
+// ErrInvalidDiceCode is returned if the code contains other characters.
+func CoinIndex(code string) (int, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || len(code) > maxBits {
+		return 0, ErrInvalidDiceCode
+	}
+	i := 0
+	for _, c := range code {
+		switch c {
+		case 'H', '0':
+			i <<= 1
+		case 'T', '1':
+			i = i<<1 | 1
+		default:
+			return 0, ErrInvalidDiceCode
+		}
+	}
+	return i, nil
+}
+
+// parseDigits parses a code of digits in the given base, using 1 as the lowest
+// digit.
+func parseDigits(code string, base int) (int, error) {
+	code = strings.TrimSpace(code)
+	if _, ok := power(base, len(code)); !ok || code == "" {
+		return 0, ErrInvalidDiceCode
+	}
+	i := 0
+	for _, c := range code {
+		d := int(c - '1')
+		if d < 0 || d >= base {
+			return 0, ErrInvalidDiceCode
+		}
+		i = i*base + d
+	}
+	return i, nil
+}
+
+// power returns base^exp. It reports false if exp is smaller than one or the
+// result exceeds maxBits bits.
+func power(base, exp int) (int, bool) {
+	if exp < 1 {
+		return 0, false
+	}
+	n := 1
+	for ; exp > 0; exp-- {
+		if n > (1<<maxBits)/base {
+			return 0, false
+		}
+		n *= base
+	}
+	return n, true
+}
@@ -0,0 +1,103 @@
+package diceware_test
+
+import (
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestDiceCode(t *testing.T) {
+	tests := []struct {
+		i, dice int
+		code    string
+	}{
+		{0, 5, "11111"},
+		{1, 5, "11112"},
+		{6, 5, "11121"},
+		{7775, 5, "66666"},
+		{5, 1, "6"},
+	}
+	for _, tt := range tests {
+		code, err := diceware.DiceCode(tt.i, tt.dice)
+		ok(t, err)
+		equals(t, tt.code, code)
+		i, err := diceware.DiceIndex(code)
+		ok(t, err)
+		equals(t, tt.i, i)
+	}
+
+	for i := 0; i < 7776; i++ {
+		code, err := diceware.DiceCode(i, 5)
+		ok(t, err)
+		j, err := diceware.DiceIndex(code)
+		ok(t, err)
+		equals(t, i, j)
+	}
+
+	for _, tt := range []struct{ i, dice int }{{-1, 5}, {7776, 5}, {0, 0}, {0, 100}} {
+		_, err := diceware.DiceCode(tt.i, tt.dice)
+		equals(t, diceware.ErrInvalidIndex, err)
+	}
+	for _, code := range []string{"", "11170", "1a111", "H11111"} {
+		_, err := diceware.DiceIndex(code)
+		equals(t, diceware.ErrInvalidDiceCode, err)
+	}
+}
+
+func TestCoinCode(t *testing.T) {
+	tests := []struct {
+		i, flips int
+		code     string
+	}{
+		{0, 13, "HHHHHHHHHHHHH"},
+		{1, 13, "HHHHHHHHHHHHT"},
+		{8191, 13, "TTTTTTTTTTTTT"},
+		{5, 3, "THT"},
+	}
+	for _, tt := range tests {
+		code, err := diceware.CoinCode(tt.i, tt.flips)
+		ok(t, err)
+		equals(t, tt.code, code)
+		i, err := diceware.CoinIndex(code)
+		ok(t, err)
+		equals(t, tt.i, i)
+	}
+
+	i, err := diceware.CoinIndex("101")
+	ok(t, err)
+	equals(t, 5, i)
+
+	for _, tt := range []struct{ i, flips int }{{-1, 13}, {8192, 13}, {0, 0}, {0, 100}} {
+		_, err := diceware.CoinCode(tt.i, tt.flips)
+		equals(t, diceware.ErrInvalidIndex, err)
+	}
+	for _, code := range []string{"", "HTX", "12"} {
+		_, err := diceware.CoinIndex(code)
+		equals(t, diceware.ErrInvalidDiceCode, err)
+	}
+}
+
+func TestWordList_DiceCode(t *testing.T) {
+	l := diceware.Diceware8k
+	for i := 0; i < l.Len(); i++ {
+		code, err := l.DiceCode(i)
+		ok(t, err)
+		j, err := l.Index(code)
+		ok(t, err)
+		equals(t, i, j)
+	}
+
+	code, err := l.DiceCode(l.Len() - 1)
+	ok(t, err)
+	equals(t, "T444444", code)
+	i, err := l.Index("h311111")
+	ok(t, err)
+	equals(t, "ej", l.Word(i))
+
+	_, err = l.DiceCode(l.Len())
+	equals(t, diceware.ErrInvalidIndex, err)
+	for _, code := range []string{"", "H", "X111111", "H11111", "H1111111", "H511111", "111111"} {
+		_, err := l.Index(code)
+		equals(t, diceware.ErrInvalidDiceCode, err)
+	}
+}
@@ -0,0 +1,165 @@
+package diceware
+
+import (
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// BitsPerWord is the amount of information encoded by a single word of the
+// diceware8k list. The list holds exactly 8192 (2^13) words.
+const BitsPerWord = 13
+
+var (
+	// ErrUnknownWord is raised when a word can't be found in the word list.
+	ErrUnknownWord = errors.New("diceware: word is not part of the word list")
+
+	// ErrInvalidEncoding is raised when a sequence of words doesn't represent
+	// validly encoded data.
+	ErrInvalidEncoding = errors.New("diceware: words don't represent encoded data")
+
+	// ErrChecksumMismatch is raised when the checksum word doesn't match the
+	// words preceding it.
+	ErrChecksumMismatch = errors.New("diceware: checksum word doesn't match")
+)
+
+var (
+	wordIndexOnce sync.Once
+	wordIndex     map[string]int
+)
+
+// lookupWord returns the index of the given word in the standard word list.
+// The word is compared case-insensitively and surrounding whitespace is
+// ignored.
+func lookupWord(word string) (int, bool) {
+	return wordID(strings.ToLower(strings.TrimSpace(word)))
+}
+
+// wordID returns the index of the given word in the standard word list. Unlike
+// lookupWord, it requires an exact match.
+func wordID(word string) (int, bool) {
+	wordIndexOnce.Do(func() {
+		wordIndex = make(map[string]int, len(diceware8k()))
+		for i, w := range diceware8k() {
+			wordIndex[w] = i
+		}
+	})
+	id, ok := wordIndex[word]
+	return id, ok
+}
+
+// Encode maps arbitrary binary data to words of the diceware8k list. Each word
+// carries 13 bits of the data. To make the encoding reversible for data of any
+// length, a single 1 bit is appended to the data and the remaining bits of the
+// last word are filled with zeros.
+func Encode(data []byte) []string {
+	words := make([]string, 0, (len(data)*8+BitsPerWord)/BitsPerWord)
+	var acc uint32
+	var bits uint
+	for _, b := range data {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= BitsPerWord {
+			bits -= BitsPerWord
+			words = append(words, diceware8k()[acc>>bits&8191])
+		}
+	}
+
+	// Append the terminating 1 bit and pad with zeros.
+	acc = acc<<1 | 1
+	bits++
+	words = append(words, diceware8k()[acc<<(BitsPerWord-bits)&8191])
+	return words
+}
+
+// Decode reverses Encode and returns the binary data represented by the given
+// words.
+func Decode(words []string) ([]byte, error) {
+	if len(words) == 0 {
+		return nil, ErrInvalidEncoding
+	}
+
+	ids := make([]int, len(words))
+	for i, word := range words {
+		id, ok := lookupWord(word)
+		if !ok {
+			return nil, ErrUnknownWord
+		}
+		ids[i] = id
+	}
+
+	// Locate the terminating 1 bit, which must be placed in the last word.
+	last := ids[len(ids)-1]
+	if last == 0 {
+		return nil, ErrInvalidEncoding
+	}
+	pad := uint(0)
+	for last&(1<<pad) == 0 {
+		pad++
+	}
+	total := len(ids)*BitsPerWord - int(pad) - 1
+	if total%8 != 0 {
+		return nil, ErrInvalidEncoding
+	}
+
+	data := make([]byte, 0, total/8)
+	var acc uint32
+	var bits uint
+	for _, id := range ids {
+		acc = acc<<BitsPerWord | uint32(id)
+		bits += BitsPerWord
+		for bits >= 8 && len(data) < total/8 {
+			bits -= 8
+			data = append(data, byte(acc>>bits))
+		}
+	}
+	return data, nil
+}
+
+// EncodeChecksum works like Encode but appends a checksum word to the encoded
+// data. See ChecksumWord for details.
+func EncodeChecksum(data []byte) []string {
+	words := Encode(data)
+	return append(words, ChecksumWord(words))
+}
+
+// DecodeChecksum verifies the trailing checksum word of the given words and
+// decodes the preceding words like Decode does.
+func DecodeChecksum(words []string) ([]byte, error) {
+	if len(words) < 2 {
+		return nil, ErrInvalidEncoding
+	}
+	if err := VerifyChecksum(words); err != nil {
+		return nil, err
+	}
+	return Decode(words[:len(words)-1])
+}
+
+// ChecksumWord calculates the checksum word for the given words. The words are
+// joined by a single whitespace and hashed using SHA-256. The first 13 bits of
+// the hash select the checksum word from the diceware8k list.
+func ChecksumWord(words []string) string {
+	normalized := make([]string, len(words))
+	for i, word := range words {
+		normalized[i] = strings.ToLower(strings.TrimSpace(word))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(normalized, " ")))
+	return diceware8k()[(int(sum[0])<<8|int(sum[1]))>>3]
+}
+
+// VerifyChecksum verifies that the last of the given words is the checksum of
+// the words preceding it.
+func VerifyChecksum(words []string) error {
+	if len(words) < 2 {
+		return ErrChecksumMismatch
+	}
+	last := len(words) - 1
+	if _, ok := lookupWord(words[last]); !ok {
+		return ErrUnknownWord
+	}
+	if ChecksumWord(words[:last]) != strings.ToLower(strings.TrimSpace(words[last])) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package diceware_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for n := 0; n < 64; n++ {
+		data := make([]byte, n)
+		_, err := rand.Read(data)
+		ok(t, err)
+
+		words := diceware.Encode(data)
+		assert(t, len(words) == (n*8+diceware.BitsPerWord)/diceware.BitsPerWord, "Unexpected amount of words for %d bytes: %d", n, len(words))
+
+		decoded, err := diceware.Decode(words)
+		ok(t, err)
+		assert(t, bytes.Equal(data, decoded), "Expected %x, got %x", data, decoded)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		words       []string
+		expectedErr error
+	}{
+		{nil, diceware.ErrInvalidEncoding},
+		{[]string{"a"}, diceware.ErrInvalidEncoding},
+		{[]string{"notaword"}, diceware.ErrUnknownWord},
+		{[]string{"a", "a&p"}, diceware.ErrInvalidEncoding},
+	}
+
+	for _, tt := range tests {
+		_, err := diceware.Decode(tt.words)
+		equals(t, tt.expectedErr, err)
+	}
+}
+
+func TestEncodeDecodeChecksum(t *testing.T) {
+	data := []byte("correct horse battery staple")
+	words := diceware.EncodeChecksum(data)
+	equals(t, len(diceware.Encode(data))+1, len(words))
+
+	decoded, err := diceware.DecodeChecksum(words)
+	ok(t, err)
+	equals(t, data, decoded)
+
+	// Swap two words which is a common transcription error.
+	words[0], words[1] = words[1], words[0]
+	_, err = diceware.DecodeChecksum(words)
+	equals(t, diceware.ErrChecksumMismatch, err)
+}
@@ -0,0 +1,94 @@
+package diceware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A WordCountError is returned if the requested amount of words can't be used
+// to build a passphrase. It matches ErrInvalidWordCount when using errors.Is.
+type WordCountError struct {
+	// Got is the requested amount of words.
+	Got int
+	// Min is the smallest allowed amount of words.
+	Min int
+	// Max is the largest allowed amount of words. It is zero if there is no
+	// upper limit.
+	Max int
+}
+
+// Error implements the error interface.
+func (e *WordCountError) Error() string {
+	if e.Max > 0 && e.Got > e.Max {
+		return fmt.Sprintf("%s: got %d, want at most %d", ErrInvalidWordCount, e.Got, e.Max)
+	}
+	return fmt.Sprintf("%s: got %d, want at least %d", ErrInvalidWordCount, e.Got, e.Min)
+}
+
+// Is reports whaether the target is ErrInvalidWordCount.
+func (e *WordCountError) Is(target error) bool {
+	return target == ErrInvalidWordCount
+}
+
+// A Rule is a standard a passphrase must met to pass the validation.
+type Rule string
+
+// The rules checked by the validation of a passphrase.
+const (
+	// RuleMinLength requires the passphrase to have at least MinPhraseLength
+	// characters, or the amount set by MinLength.
+	RuleMinLength Rule = "min_length"
+
+	// RuleMaxLength requires the passphrase to have at most the amount of
+	// characters set by MaxLength.
+	RuleMaxLength Rule = "max_length"
+
+	// RuleMinWords requires the passphrase to have at least DefaultWords words.
+	RuleMinWords Rule = "min_words"
+)
+
+// A Violation describes a Rule a passphrase doesn't met.
+type Violation struct {
+	Rule  Rule `json:"rule"`
+	Got   int  `json:"got"`
+	Limit int  `json:"limit"`
+}
+
+// String implements the Stringer interface.
+func (v Violation) String() string {
+	switch v.Rule {
+	case RuleMinLength:
+		return fmt.Sprintf("length %d is below %d", v.Got, v.Limit)
+	case RuleMaxLength:
+		return fmt.Sprintf("length %d is above %d", v.Got, v.Limit)
+	case RuleMinWords:
+		return fmt.Sprintf("%d words are less than %d", v.Got, v.Limit)
+	}
+	return fmt.Sprintf("%s: got %d, limit %d", v.Rule, v.Got, v.Limit)
+}
+
+// A ValidationError is returned if a generated passphrase doesn't met the
+// standards checked by Validate. It matches ErrValidationFailed when using
+// errors.Is.
+type ValidationError struct {
+	Rules []Violation
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	rules := make([]string, len(e.Rules))
+	for i, v := range e.Rules {
+		rules[i] = v.String()
+	}
+	return fmt.Sprintf("%s: %s", ErrValidationFailed, strings.Join(rules, ", "))
+}
+
+// Is reports whaether the target is ErrValidationFailed.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidationFailed
+}
+
+// sourceError wraps an error returned by the source of randomness.
+func sourceError(err error) error {
+	return fmt.Errorf("diceware: reading random source: %w", err)
+}
@@ -0,0 +1,45 @@
+package diceware_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestWordCountError(t *testing.T) {
+	_, err := diceware.NewPassphrase(diceware.Words(0))
+	var wcErr *diceware.WordCountError
+	assert(t, errors.As(err, &wcErr), "Expected *WordCountError, got %T.", err)
+	equals(t, diceware.WordCountError{Got: 0, Min: diceware.MinWords}, *wcErr)
+	assert(t, errors.Is(err, diceware.ErrInvalidWordCount), "Expected error to match ErrInvalidWordCount.")
+	equals(t, "diceware: amount of words is invalid: got 0, want at least 1", err.Error())
+
+	_, err = diceware.NewPassphrase(diceware.Unique(true), diceware.Words(diceware.Diceware8k.Len()+1))
+	assert(t, errors.As(err, &wcErr), "Expected *WordCountError, got %T.", err)
+	equals(t, diceware.WordCountError{Got: diceware.Diceware8k.Len() + 1, Min: diceware.MinWords, Max: diceware.Diceware8k.Len()}, *wcErr)
+	equals(t, "diceware: amount of words is invalid: got 8193, want at most 8192", err.Error())
+}
+
+func TestValidationError(t *testing.T) {
+	_, err := diceware.NewPassphrase(diceware.Words(2))
+	var vErr *diceware.ValidationError
+	assert(t, errors.As(err, &vErr), "Expected *ValidationError, got %T.", err)
+	assert(t, errors.Is(err, diceware.ErrValidationFailed), "Expected error to match ErrValidationFailed.")
+
+	// Two words may or may not violate the length, but always the word count.
+	last := vErr.Rules[len(vErr.Rules)-1]
+	equals(t, diceware.Violation{Rule: diceware.RuleMinWords, Got: 2, Limit: diceware.DefaultWords}, last)
+	assert(t, strings.HasSuffix(err.Error(), "2 words are less than 6"), "Unexpected error message %q.", err)
+}
+
+func TestSourceError(t *testing.T) {
+	// The derivation source is exhausted after 8160 bytes, 8 bytes are read
+	// per word.
+	_, err := diceware.Derive(seed, "example.com", diceware.Words(1100))
+	assert(t, err != nil, "Expected error from exhausted source.")
+	assert(t, strings.HasPrefix(err.Error(), "diceware: reading random source: "), "Unexpected error message %q.", err)
+	assert(t, errors.Is(err, io.ErrUnexpectedEOF), "Expected wrapped io.ErrUnexpectedEOF, got %v.", err)
+}
@@ -0,0 +1,383 @@
+package diceware
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// maxEstimateLength is the amount of characters Estimate analyzes. Characters
+// beyond are ignored which only leads to an underestimation of the strength.
+const maxEstimateLength = 256
+
+// Feedback given by Estimate.
+const (
+	FeedbackAddWords       = "Add another word or two. Uncommon words are better."
+	FeedbackRepeats        = "Avoid repeated words and characters."
+	FeedbackKeyboard       = "Avoid keyboard patterns like qwerty or asdf."
+	FeedbackSequences      = "Avoid sequences like abc or 1234."
+	FeedbackSubstitutions  = "Predictable substitutions like '@' instead of 'a' don't help very much."
+	FeedbackCapitalization = "Capitalization doesn't help very much."
+)
+
+// Strength is the result of a password strength estimation.
+type Strength struct {
+	// Guesses is the estimated amount of guesses needed to crack the password.
+	Guesses float64
+
+	// Entropy is the binary logarithm of Guesses.
+	Entropy float64
+
+	// Feedback contains human readable suggestions on how to improve the
+	// password.
+	Feedback []string
+}
+
+// Pattern types recognized by Estimate.
+const (
+	patternBruteforce = iota
+	patternDictionary
+	patternRepeat
+	patternSequence
+	patternKeyboard
+	patternSeparator
+)
+
+// separators are characters commonly used to separate the words of a
+// passphrase, ordered by popularity.
+const separators = " -_.,/+:;|"
+
+// A match is a part of a password which matches a pattern. Its strength is
+// measured in bits (the binary logarithm of the guesses).
+type match struct {
+	i, j    int
+	bits    float64
+	pattern int
+	word    string
+	leet    bool
+	caps    bool
+}
+
+// keyboardRows are the rows of a QWERTY keyboard without and with shift.
+var keyboardRows = []string{
+	"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./",
+	"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?",
+}
+
+// leet maps common character substitutions back to letters.
+var leet = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '{': 'c', '3': 'e', '6': 'g',
+	'9': 'g', '1': 'i', '!': 'i', '|': 'i', '0': 'o', '$': 's', '5': 's',
+	'7': 't', '+': 't', '2': 'z', '%': 'x',
+}
+
+// Estimate estimates the strength of an arbitrary password. It splits the
+// password into the most guessable combination of words of the word list
+// (including common substitutions and capitalization), repeats, sequences,
+// keyboard patterns, separators and single characters. The guesses needed for
+// the parts are multiplied to obtain the guesses needed for the whole password.
+// Repeated words are only counted once and a separator repeated between words
+// is only guessed once, e.g. the spaces of a passphrase.
+//
+// The estimation is an approximation of the strategy a sophisticated attacker
+// would use. It must not be mistaken for the entropy of a generated passphrase,
+// which is exactly known (see Passphrase.Entropy).
+func Estimate(password string) Strength {
+	r := []rune(password)
+	if len(r) > maxEstimateLength {
+		r = r[:maxEstimateLength]
+	}
+
+	path := bestPath(r)
+
+	var (
+		bits     float64
+		seen     = make(map[string]bool)
+		seps     = make(map[string]bool)
+		words    int
+		patterns = make(map[int]bool)
+		leeted   bool
+		capped   bool
+	)
+	for _, m := range path {
+		if m.pattern == patternDictionary {
+			words++
+			if seen[m.word] {
+				// An attacker only has to guess which of the preceding words is
+				// repeated.
+				m.bits = math.Max(1, math.Log2(float64(len(seen))))
+				patterns[patternRepeat] = true
+			}
+			seen[m.word] = true
+			leeted = leeted || m.leet
+			capped = capped || m.caps
+		}
+		if m.pattern == patternSeparator {
+			// An attacker guesses the separator once for all words.
+			if seps[m.word] {
+				m.bits = 0
+			}
+			seps[m.word] = true
+		}
+		patterns[m.pattern] = true
+		bits += m.bits
+	}
+
+	s := Strength{
+		Guesses: math.Pow(2, bits),
+		Entropy: bits,
+	}
+	if bits < float64(DefaultWords*BitsPerWord) {
+		s.Feedback = append(s.Feedback, FeedbackAddWords)
+	}
+	if patterns[patternRepeat] {
+		s.Feedback = append(s.Feedback, FeedbackRepeats)
+	}
+	if patterns[patternKeyboard] {
+		s.Feedback = append(s.Feedback, FeedbackKeyboard)
+	}
+	if patterns[patternSequence] {
+		s.Feedback = append(s.Feedback, FeedbackSequences)
+	}
+	if leeted {
+		s.Feedback = append(s.Feedback, FeedbackSubstitutions)
+	}
+	if capped {
+		s.Feedback = append(s.Feedback, FeedbackCapitalization)
+	}
+	return s
+}
+
+// bestPath returns the sequence of matches covering the password which needs
+// the least amount of guesses.
+func bestPath(r []rune) []match {
+	matches := make([][]match, len(r)+1)
+	for _, m := range findMatches(r) {
+		matches[m.j] = append(matches[m.j], m)
+	}
+
+	bits := make([]float64, len(r)+1)
+	prev := make([]match, len(r)+1)
+	for j := 1; j <= len(r); j++ {
+		bits[j] = math.Inf(1)
+		for _, m := range matches[j] {
+			if b := bits[m.i] + m.bits; b < bits[j] {
+				bits[j] = b
+				prev[j] = m
+			}
+		}
+	}
+
+	var path []match
+	for j := len(r); j > 0; j = prev[j].i {
+		path = append([]match{prev[j]}, path...)
+	}
+	return path
+}
+
+// findMatches returns all matches found in the password.
+func findMatches(r []rune) []match {
+	var matches []match
+	for i, c := range r {
+		matches = append(matches, match{i: i, j: i + 1, bits: math.Log2(cardinality(c))})
+		if k := strings.IndexRune(separators, c); k >= 0 {
+			matches = append(matches, match{
+				i:       i,
+				j:       i + 1,
+				bits:    math.Log2(float64(k + 1)),
+				pattern: patternSeparator,
+				word:    string(c),
+			})
+		}
+	}
+	matches = append(matches, dictionaryMatches(r)...)
+	matches = append(matches, repeatMatches(r)...)
+	matches = append(matches, sequenceMatches(r)...)
+	matches = append(matches, keyboardMatches(r)...)
+	return matches
+}
+
+// cardinality returns the size of the character class of c.
+func cardinality(c rune) float64 {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return 26
+	case c >= '0' && c <= '9':
+		return 10
+	case c < unicode.MaxASCII:
+		return 33
+	}
+	return 100
+}
+
+// dictionaryMatches finds words of the word list, which might be capitalized
+// or contain common substitutions.
+func dictionaryMatches(r []rune) []match {
+	listAlphabet()
+	listBits := math.Log2(float64(len(diceware8k())))
+
+	var matches []match
+	for i := range r {
+		for j := i + 1; j <= len(r) && j-i <= maxWordLen; j++ {
+			token := string(r[i:j])
+			lower := strings.ToLower(token)
+			m := match{i: i, j: j, bits: listBits, pattern: patternDictionary, word: lower}
+
+			if _, ok := wordID(lower); !ok {
+				subs := 0
+				unleeted := []rune(lower)
+				for k, c := range unleeted {
+					if l, ok := leet[c]; ok {
+						unleeted[k] = l
+						subs++
+					}
+				}
+				if _, ok := wordID(string(unleeted)); subs == 0 || !ok {
+					continue
+				}
+				m.word = string(unleeted)
+				m.leet = true
+				m.bits += float64(subs)
+			}
+
+			if lower != token {
+				m.caps = true
+				m.bits += capitalizationBits(r[i:j])
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// capitalizationBits returns the additional bits needed to guess the
+// capitalization of a word.
+func capitalizationBits(r []rune) float64 {
+	var upper, lower int
+	for _, c := range r {
+		if unicode.IsUpper(c) {
+			upper++
+		} else if unicode.IsLower(c) {
+			lower++
+		}
+	}
+	if lower == 0 || (upper == 1 && unicode.IsUpper(r[0])) {
+		return 1
+	}
+
+	// Sum the possibilities of placing up to the amount of upper (or lower)
+	// case letters.
+	n, k := upper+lower, upper
+	if lower < k {
+		k = lower
+	}
+	var variations float64
+	for i := 1; i <= k; i++ {
+		variations += binomial(n, i)
+	}
+	return math.Log2(variations)
+}
+
+func binomial(n, k int) float64 {
+	r := 1.0
+	for i := 1; i <= k; i++ {
+		r = r * float64(n-k+i) / float64(i)
+	}
+	return r
+}
+
+// repeatMatches finds repeated parts like "abcabc" or "aaa". Starting from
+// the left, it finds the repeat which covers the most characters and continues
+// behind it.
+func repeatMatches(r []rune) []match {
+	var matches []match
+	for i := 0; i < len(r); {
+		best := match{i: i, j: i}
+		var base []rune
+		for l := 1; i+2*l <= len(r); l++ {
+			count := 1
+			for i+(count+1)*l <= len(r) && string(r[i+count*l:i+(count+1)*l]) == string(r[i:i+l]) {
+				count++
+			}
+			if count >= 2 && count*l >= 3 && i+count*l > best.j {
+				best.j = i + count*l
+				best.bits = math.Log2(float64(count))
+				base = r[i : i+l]
+			}
+		}
+		if base == nil {
+			i++
+			continue
+		}
+		for _, m := range bestPath(base) {
+			best.bits += m.bits
+		}
+		best.pattern = patternRepeat
+		matches = append(matches, best)
+		i = best.j
+	}
+	return matches
+}
+
+// sequenceMatches finds sequences like "abcd", "9876" or "ACEG".
+func sequenceMatches(r []rune) []match {
+	var matches []match
+	for i := 0; i+2 < len(r); {
+		delta := r[i+1] - r[i]
+		j := i + 1
+		for j < len(r) && r[j]-r[j-1] == delta && delta != 0 && delta >= -2 && delta <= 2 && sameClass(r[i], r[j]) {
+			j++
+		}
+		if j-i >= 3 {
+			matches = append(matches, match{
+				i:       i,
+				j:       j,
+				bits:    math.Log2(cardinality(r[i]) * 4 * float64(j-i)),
+				pattern: patternSequence,
+			})
+			i = j - 1
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+func sameClass(a, b rune) bool {
+	return unicode.IsLower(a) == unicode.IsLower(b) &&
+		unicode.IsUpper(a) == unicode.IsUpper(b) &&
+		unicode.IsDigit(a) == unicode.IsDigit(b) &&
+		(unicode.IsLetter(a) || unicode.IsDigit(a)) && (unicode.IsLetter(b) || unicode.IsDigit(b))
+}
+
+// keyboardMatches finds runs of horizontally adjacent keys like "qwerty" or
+// "lkjh".
+func keyboardMatches(r []rune) []match {
+	var matches []match
+	for _, row := range keyboardRows {
+		for i := 0; i+2 < len(r); {
+			j := i + 1
+			dir := 0
+			for j < len(r) {
+				a, b := strings.IndexRune(row, r[j-1]), strings.IndexRune(row, r[j])
+				if a < 0 || b < 0 || (b-a != 1 && b-a != -1) || (dir != 0 && b-a != dir) {
+					break
+				}
+				dir = b - a
+				j++
+			}
+			if j-i >= 3 {
+				matches = append(matches, match{
+					i:       i,
+					j:       j,
+					bits:    math.Log2(float64(len(keyboardRows)*len(row)) * 2 * float64(j-i)),
+					pattern: patternKeyboard,
+				})
+				i = j - 1
+				continue
+			}
+			i++
+		}
+	}
+	return matches
+}
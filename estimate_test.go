@@ -0,0 +1,50 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		password string
+		min, max float64
+		feedback string
+	}{
+		{"", 0, 0, diceware.FeedbackAddWords},
+		{"qwerty", 0, 15, diceware.FeedbackKeyboard},
+		{"abcdefgh", 0, 15, diceware.FeedbackSequences},
+		{"12345678", 0, 15, diceware.FeedbackSequences},
+		{"aaaaaaaaaaaa", 0, 15, diceware.FeedbackRepeats},
+		{"lofty lofty lofty lofty lofty lofty", 0, 40, diceware.FeedbackRepeats},
+		{"l0fty g3353", 0, 40, diceware.FeedbackSubstitutions},
+		{"Lofty", 0, 15, diceware.FeedbackCapitalization},
+		{"lofty geese borne loess covet ff", 70, 78, ""},
+		{"lofty-geese-borne-loess-covet-ff", 70, 79, ""},
+		{"loftygeeseborneloesscovetff", 70, 78, ""},
+		{"Tr0ub4dor&3", 20, 60, ""},
+	}
+
+	for _, tt := range tests {
+		s := diceware.Estimate(tt.password)
+		assert(t, tt.min <= s.Entropy && s.Entropy <= tt.max, "%q: expected entropy in [%v, %v], got %v", tt.password, tt.min, tt.max, s.Entropy)
+		if tt.feedback != "" {
+			assert(t, strings.Contains(strings.Join(s.Feedback, "\\n"), tt.feedback), "%q: expected feedback %q, got %q", tt.password, tt.feedback, s.Feedback)
+		}
+	}
+
+	// The estimation must never exceed the entropy of a generated passphrase.
+	for i := 0; i < 100; i++ {
+		phrase, err := diceware.NewPassphrase(diceware.Extra(i%2 == 0), diceware.Validate(false))
+		ok(t, err)
+		for _, password := range []string{phrase.Humanize(), phrase.String()} {
+			s := diceware.Estimate(password)
+			assert(t, s.Entropy <= phrase.Entropy(), "%q: expected entropy of at most %v, got %v", password, phrase.Entropy(), s.Entropy)
+		}
+	}
+
+	// Very long passwords must not take forever.
+	diceware.Estimate(strings.Repeat("a", 10000))
+}
@@ -0,0 +1,88 @@
+package diceware
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Diceware8kFingerprint is the published fingerprint of the Diceware8k list.
+const Diceware8kFingerprint = "2f350b5a5537defac0871e152679ecd54b1e3a1fa11b6bc40adf37d31507a6c4"
+
+var (
+	// ErrInvalidWordList is raised when a word list contains empty words,
+	// words with whitespace or duplicate words. Duplicates would silently
+	// reduce the entropy of passphrases.
+	ErrInvalidWordList = errors.New("diceware: word list is invalid")
+
+	// ErrFingerprintMismatch is raised when a word list doesn't match the
+	// expected fingerprint.
+	ErrFingerprintMismatch = errors.New("diceware: word list fingerprint mismatch")
+)
+
+// ReadWordList reads a word list with one word per line, e.g. a downloaded
+// list. ErrInvalidWordList is returned if the list contains empty words, words
+// with whitespace or duplicate words. Use Verify to check the list against a
+// published fingerprint.
+func ReadWordList(name string, r io.Reader) (*WordList, error) {
+	var words []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		words = append(words, strings.TrimSuffix(s.Text(), "\r"))
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := checkWords(words); err != nil {
+		return nil, err
+	}
+
+	l := &WordList{name: name, words: words, fingerprint: fingerprint(words)}
+	l.once.Do(func() {})
+	return l, nil
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the list in hex. It is the sum
+// of the words, each followed by a newline, so it matches the output of
+// sha256sum for the list file.
+func (l *WordList) Fingerprint() string {
+	l.load()
+	return l.fingerprint
+}
+
+// Verify verifies the list against the given fingerprint. It returns
+// ErrFingerprintMismatch if the fingerprints differ.
+func (l *WordList) Verify(fingerprint string) error {
+	if !strings.EqualFold(l.Fingerprint(), strings.TrimSpace(fingerprint)) {
+		return ErrFingerprintMismatch
+	}
+	return nil
+}
+
+// fingerprint returns the SHA-256 fingerprint of the given words.
+func fingerprint(words []string) string {
+	h := sha256.New()
+	for _, word := range words {
+		io.WriteString(h, word+"\n")
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkWords verifies that the words are not empty, contain no whitespace and
+// are unique.
+func checkWords(words []string) error {
+	if len(words) == 0 {
+		return ErrInvalidWordList
+	}
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if f := strings.Fields(word); len(f) != 1 || f[0] != word || seen[word] {
+			return ErrInvalidWordList
+		}
+		seen[word] = true
+	}
+	return nil
+}
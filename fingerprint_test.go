@@ -0,0 +1,52 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestWordList_Fingerprint(t *testing.T) {
+	equals(t, diceware.Diceware8kFingerprint, diceware.Diceware8k.Fingerprint())
+	ok(t, diceware.Diceware8k.Verify(diceware.Diceware8kFingerprint))
+	ok(t, diceware.Diceware8k.Verify(strings.ToUpper(diceware.Diceware8kFingerprint)))
+	equals(t, diceware.ErrFingerprintMismatch, diceware.Diceware8k.Verify("00"))
+
+	// The fingerprint of the built-in list is the one of its file.
+	var b strings.Builder
+	for i := 0; i < diceware.Diceware8k.Len(); i++ {
+		b.WriteString(diceware.Diceware8k.Word(i) + "\n")
+	}
+	l, err := diceware.ReadWordList("copy", strings.NewReader(b.String()))
+	ok(t, err)
+	equals(t, "copy", l.Name())
+	equals(t, diceware.Diceware8k.Len(), l.Len())
+	ok(t, l.Verify(diceware.Diceware8kFingerprint))
+
+	// sha256sum of "a\nb\n".
+	l, err = diceware.ReadWordList("ab", strings.NewReader("a\r\nb\r\n"))
+	ok(t, err)
+	equals(t, "a", l.Word(0))
+	equals(t, "911169ddaaf146aff539f58c26c489af3b892dff0fe283c1c264c65ae5aa59a2", l.Fingerprint())
+}
+
+func TestReadWordList(t *testing.T) {
+	tests := []string{
+		"",
+		"a\na\n",
+		"a\n\nb\n",
+		"a b\n",
+		" a\n",
+	}
+	for _, tt := range tests {
+		_, err := diceware.ReadWordList("test", strings.NewReader(tt))
+		equals(t, diceware.ErrInvalidWordList, err)
+	}
+}
+
+func TestPassphrase_Fingerprint(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(diceware.Validate(false))
+	ok(t, err)
+	equals(t, diceware.Diceware8kFingerprint, phrase.Metadata().Fingerprint)
+}
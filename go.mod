@@ -0,0 +1,3 @@
+module github.com/lukasmalkmus/diceware
+
+go 1.17
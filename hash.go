@@ -0,0 +1,183 @@
+package diceware
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultIterations is the default amount of PBKDF2 iterations.
+	// Ref: https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#pbkdf2
+	DefaultIterations = 600000
+
+	// DefaultSaltLength is the default length of the PBKDF2 salt in bytes.
+	DefaultSaltLength = 16
+
+	// DefaultKeyLength is the default length of the PBKDF2 output in bytes.
+	DefaultKeyLength = 32
+
+	// MaxIterations is the largest amount of PBKDF2 iterations accepted. It
+	// prevents hashes from untrusted sources from consuming unbounded time.
+	MaxIterations = 10000000
+
+	// MaxKeyLength is the largest length of the PBKDF2 output in bytes
+	// accepted.
+	MaxKeyLength = 64
+)
+
+// ErrInvalidHash is raised when a hash string can't be parsed.
+var ErrInvalidHash = errors.New("diceware: hash has an invalid format")
+
+// A Hasher hashes passwords for storage and verifies passwords against stored
+// hashes.
+type Hasher interface {
+	// Hash returns the hash of the password as string.
+	Hash(password []byte) (string, error)
+
+	// Verify reports whether the password matches the hash.
+	Verify(hash string, password []byte) (bool, error)
+}
+
+// DefaultHasher is the Hasher used by Hash and Verify.
+var DefaultHasher Hasher = PBKDF2{
+	Iterations: DefaultIterations,
+	SaltLength: DefaultSaltLength,
+	KeyLength:  DefaultKeyLength,
+}
+
+// PBKDF2 is a Hasher which implements PBKDF2 (RFC 8018) with HMAC-SHA256. The
+// hashes are encoded in the PHC string format:
+//
+//	$pbkdf2-sha256$i=<iterations>$<salt>$<hash>
+//
+// Salt and hash are encoded in base64 without padding.
+// Ref: https://github.com/P-H-C/phc-string-format/blob/master/phc-sf-spec.md
+type PBKDF2 struct {
+	Iterations int
+	SaltLength int
+	KeyLength  int
+}
+
+// phcID is the PHC identifier of PBKDF2 with HMAC-SHA256.
+const phcID = "pbkdf2-sha256"
+
+// Hash implements the Hasher interface.
+func (h PBKDF2) Hash(password []byte) (string, error) {
+	if h.Iterations < 1 || h.Iterations > MaxIterations || h.SaltLength < 1 || h.KeyLength < 1 || h.KeyLength > MaxKeyLength {
+		return "", fmt.Errorf("diceware: invalid PBKDF2 parameters %+v", h)
+	}
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := pbkdf2(password, salt, h.Iterations, h.KeyLength)
+	return fmt.Sprintf("$%s$i=%d$%s$%s", phcID, h.Iterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements the Hasher interface. The parameters are read from the
+// hash, so hashes created with different parameters can be verified as well.
+// Hashes with more than MaxIterations iterations or keys longer than
+// MaxKeyLength are rejected. The comparison is performed in constant time.
+func (h PBKDF2) Verify(hash string, password []byte) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != phcID {
+		return false, ErrInvalidHash
+	}
+	iterations, err := parseIterations(parts[2])
+	if err != nil {
+		return false, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 || len(key) > MaxKeyLength {
+		return false, ErrInvalidHash
+	}
+	actual := pbkdf2(password, salt, iterations, len(key))
+	return subtle.ConstantTimeCompare(key, actual) == 1, nil
+}
+
+// parseIterations parses the iteration parameter of a hash, e.g. "i=600000".
+// The whole parameter must match and the value must be written without sign or
+// leading zeros.
+func parseIterations(param string) (int, error) {
+	value := strings.TrimPrefix(param, "i=")
+	iterations, err := strconv.Atoi(value)
+	if value == param || err != nil || strconv.Itoa(iterations) != value ||
+		iterations < 1 || iterations > MaxIterations {
+		return 0, ErrInvalidHash
+	}
+	return iterations, nil
+}
+
+// pbkdf2 derives a key of the given length from the password and salt.
+func pbkdf2(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	key := make([]byte, 0, keyLen+prf.Size())
+	var (
+		u     []byte
+		block [4]byte
+	)
+	for i := uint32(1); len(key) < keyLen; i++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(block[:], i)
+		prf.Write(block[:])
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		for n := 1; n < iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// Hash returns the hash of the passphrase created by the DefaultHasher. The
+// passphrase is hashed in the form returned by String.
+func (p Passphrase) Hash() (string, error) {
+	return p.HashWith(DefaultHasher)
+}
+
+// HashWith returns the hash of the passphrase created by the given Hasher. The
+// passphrase is hashed in the form returned by String, but without creating a
+// string. The plaintext bytes are cleared after hashing.
+func (p Passphrase) HashWith(h Hasher) (string, error) {
+	n := 0
+	for _, word := range p.words {
+		n += len(word)
+	}
+	buf := make([]byte, 0, n)
+	for _, word := range p.words {
+		buf = append(buf, word...)
+	}
+	defer func() {
+		for i := range buf {
+			buf[i] = 0
+		}
+	}()
+	return h.Hash(buf)
+}
+
+// Verify reports whether the input matches the hash using the DefaultHasher.
+func Verify(hash string, input []byte) (bool, error) {
+	return DefaultHasher.Verify(hash, input)
+}
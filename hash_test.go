@@ -0,0 +1,55 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPBKDF2(t *testing.T) {
+	// Ref: https://tools.ietf.org/html/rfc7914#section-11
+	hasher := diceware.PBKDF2{Iterations: 1, SaltLength: 16, KeyLength: 32}
+	valid, err := hasher.Verify("$pbkdf2-sha256$i=1$c2FsdA$VawEblbjCJ/sFpHCJUS2BflBhSFt3gRl5oudV8INrLw", []byte("passwd"))
+	ok(t, err)
+	assert(t, valid, "Expected RFC 7914 test vector to verify.")
+
+	tests := []struct {
+		hash        string
+		expectedErr error
+	}{
+		{"", diceware.ErrInvalidHash},
+		{"$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=0$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=1$c2FsdA$!!!", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=1,x=2$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=10abc$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=+1$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=01$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$1$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=10000001$c2FsdA$c2FsdA", diceware.ErrInvalidHash},
+		{"$pbkdf2-sha256$i=1$c2FsdA$" + strings.Repeat("A", 88), diceware.ErrInvalidHash},
+	}
+
+	for _, tt := range tests {
+		_, err := hasher.Verify(tt.hash, []byte("passwd"))
+		equals(t, tt.expectedErr, err)
+	}
+}
+
+func TestPassphrase_Hash(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(diceware.Validate(false))
+	ok(t, err)
+
+	hash, err := phrase.Hash()
+	ok(t, err)
+	assert(t, strings.HasPrefix(hash, "$pbkdf2-sha256$i=600000$"), "Unexpected hash format: %s", hash)
+
+	valid, err := diceware.Verify(hash, []byte(phrase.String()))
+	ok(t, err)
+	assert(t, valid, "Expected passphrase to match its hash.")
+
+	valid, err = diceware.Verify(hash, []byte(phrase.Humanize()))
+	ok(t, err)
+	assert(t, !valid, "Expected different input not to match the hash.")
+}
@@ -0,0 +1,267 @@
+package diceware
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/big"
+)
+
+var (
+	// ErrInvalidLength is raised when the length bounds are invalid or can't
+	// be met.
+	ErrInvalidLength = errors.New("diceware: length bounds can't be met")
+
+	// ErrIncompatibleOptions is raised when Options are combined which
+	// exclude each other, e.g. length bounds and the Checksum Option.
+	ErrIncompatibleOptions = errors.New("diceware: options can't be combined")
+)
+
+// MinLength is an Option that defines the minimum amount of characters of the
+// passphrase (as returned by String). See MaxLength for details.
+func MinLength(length int) Option {
+	return func(p *Passphrase) error { return p.setMinLength(length) }
+}
+func (p *Passphrase) setMinLength(length int) error {
+	if length < 1 {
+		return ErrInvalidLength
+	}
+	p.minLength = length
+	return nil
+}
+
+// MaxLength is an Option that defines the maximum amount of characters of the
+// passphrase (as returned by String), e.g. for systems which limit the length
+// of passwords.
+//
+// If length bounds are set, the passphrase is picked uniformly from all
+// passphrases within the bounds. Since this excludes passphrases, the amount
+// of words is chosen as the smallest amount which still provides the entropy of
+// a passphrase with the amount of words set by the Words Option (or the entropy
+// set by the MinEntropy Option). The Entropy of
+// the passphrase is calculated exactly. ErrInvalidLength is returned if no
+// amount of words provides this entropy within the bounds.
+//
+// Length bounds can't be combined with the Checksum, Unique, MinEditDistance
+// and DistinctSounds Options, ErrIncompatibleOptions is returned instead.
+func MaxLength(length int) Option {
+	return func(p *Passphrase) error { return p.setMaxLength(length) }
+}
+func (p *Passphrase) setMaxLength(length int) error {
+	if length < 1 {
+		return ErrInvalidLength
+	}
+	p.maxLength = length
+	return nil
+}
+
+// bounded reports whether the length of the passphrase is bounded.
+func (p Passphrase) bounded() bool {
+	return p.minLength > 0 || p.maxLength > 0
+}
+
+// wordsPicked returns the amount of words picked for the passphrase, which is
+// chosen within the length bounds if they are set.
+func (p Passphrase) wordsPicked() int {
+	if p.bounded() {
+		return p.boundedWords
+	}
+	return p.wordCount
+}
+
+// lengthBounds returns the bounds of the total length of the words without the
+// extra. If no maximum is set, hi is -1.
+func (p Passphrase) lengthBounds() (lo, hi int) {
+	lo, hi = p.minLength, p.maxLength
+	if p.validate && lo == 0 {
+		lo = MinPhraseLength
+	}
+	if hi == 0 {
+		hi = -1
+	}
+	if p.extra {
+		lo--
+		if hi > 0 {
+			hi--
+		}
+	}
+	return lo, hi
+}
+
+// byLength returns the IDs of the words of the list grouped by length.
+func (l *WordList) byLength() [][]int64 {
+	l.lengthsOnce.Do(func() {
+		for i, word := range l.load() {
+			for len(l.lengths) <= len(word) {
+				l.lengths = append(l.lengths, nil)
+			}
+			l.lengths[len(word)] = append(l.lengths[len(word)], int64(i))
+		}
+	})
+	return l.lengths
+}
+
+// lengthTable holds the amount of word sequences by amount of words and total
+// length of the words.
+type lengthTable [][]*big.Int
+
+// newLengthTable returns a table which only holds the empty sequence. Rows are
+// added by grow.
+func newLengthTable() lengthTable {
+	return lengthTable{{big.NewInt(1)}}
+}
+
+// grow adds the row of sequences with one word more than the last row. The
+// lengths hold the IDs of the words grouped by length.
+func (t lengthTable) grow(lengths [][]int64) lengthTable {
+	maxLen := len(lengths) - 1
+
+	prevRow := t[len(t)-1]
+	row := make([]*big.Int, len(prevRow)+maxLen)
+	for total := range row {
+		row[total] = new(big.Int)
+	}
+	for prev, n := range prevRow {
+		for l := 1; l <= maxLen; l++ {
+			if c := len(lengths[l]); c > 0 {
+				row[prev+l].Add(row[prev+l], new(big.Int).Mul(n, big.NewInt(int64(c))))
+			}
+		}
+	}
+	return append(t, row)
+}
+
+// count returns the amount of sequences of r words with a total length within
+// [lo, hi]. If hi is negative, there is no maximum.
+func (t lengthTable) count(r, lo, hi int) *big.Int {
+	sum := new(big.Int)
+	for total, n := range t[r] {
+		if total >= lo && (hi < 0 || total <= hi) {
+			sum.Add(sum, n)
+		}
+	}
+	return sum
+}
+
+// resolveLengthBounds chooses the amount of words of a passphrase with length
+// bounds and calculates the entropy of the words. Rows of the length table are
+// only added until the amount of words is found. The results are stored, so
+// they aren't recalculated by Entropy and Regenerate.
+func (p *Passphrase) resolveLengthBounds() error {
+	if !p.bounded() {
+		return nil
+	}
+	if p.checksum || p.unique || p.distinctSounds || p.minEditDistance > 1 {
+		return ErrIncompatibleOptions
+	}
+
+	lo, hi := p.lengthBounds()
+	if hi >= 0 && hi < lo {
+		return ErrInvalidLength
+	}
+	target := p.targetBits()
+	// Every word has at least one character, so lo words always reach the
+	// minimum length.
+	limit := 2 * int(math.Ceil(target/math.Log2(float64(p.list.Len()))))
+	if lo+1 > limit {
+		limit = lo + 1
+	}
+	if hi >= 0 {
+		limit = hi
+	}
+
+	t := newLengthTable()
+	for k := 1; k <= limit; k++ {
+		t = t.grow(p.list.byLength())
+		bits := log2(t.count(k, lo, hi))
+		total := bits
+		if p.minEntropy > 0 && p.extra {
+			total += math.Log2(float64(len(extras))) + math.Log2(float64(k))
+		}
+		if total >= target {
+			p.lengths, p.boundedWords, p.boundedBits = t, k, bits
+			return nil
+		}
+	}
+	return ErrInvalidLength
+}
+
+// generateBounded returns the IDs of the words of a passphrase with length
+// bounds. It picks the length of every word with a probability proportional to
+// the amount of passphrases which can be completed within the bounds. This
+// results in a uniform distribution over all passphrases within the bounds.
+func (p *Passphrase) generateBounded() ([]int64, error) {
+	k, t := p.boundedWords, p.lengths
+	lo, hi := p.lengthBounds()
+	lengths := p.list.byLength()
+
+	ids := make([]int64, k)
+	used := 0
+	for i := range ids {
+		r := k - i - 1
+		weights := make([]*big.Int, len(lengths))
+		total := new(big.Int)
+		for l := 1; l < len(lengths); l++ {
+			switch {
+			case hi < 0:
+				weights[l] = t.count(r, lo-used-l, -1)
+			case hi-used-l < 0:
+				weights[l] = new(big.Int)
+			default:
+				weights[l] = t.count(r, lo-used-l, hi-used-l)
+			}
+			weights[l].Mul(weights[l], big.NewInt(int64(len(lengths[l]))))
+			total.Add(total, weights[l])
+		}
+
+		n, err := generateBigID(p.source, total)
+		if err != nil {
+			return nil, err
+		}
+		l := 1
+		for ; n.Cmp(weights[l]) >= 0; l++ {
+			n.Sub(n, weights[l])
+		}
+
+		id, err := generateID(p.source, int64(len(lengths[l])))
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = lengths[l][id]
+		used += l
+	}
+	return ids, nil
+}
+
+// generateBigID returns a uniformly distributed integer in [0, n) read from
+// the given source. It reads as many bytes as needed to represent n and
+// rejects values which are too large. Errors of the source are wrapped.
+func generateBigID(r io.Reader, n *big.Int) (*big.Int, error) {
+	bits := n.BitLen()
+	buf := make([]byte, (bits+7)/8)
+	v := new(big.Int)
+	for {
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, sourceError(err)
+		}
+		if extra := uint(len(buf)*8 - bits); extra > 0 {
+			buf[0] &= byte(0xff >> extra)
+		}
+		if v.SetBytes(buf).Cmp(n) < 0 {
+			return v, nil
+		}
+	}
+}
+
+// log2 returns the binary logarithm of n.
+func log2(n *big.Int) float64 {
+	if n.Sign() <= 0 {
+		return math.Inf(-1)
+	}
+	shift := n.BitLen() - 53
+	if shift < 0 {
+		shift = 0
+	}
+	f, _ := new(big.Float).SetInt(new(big.Int).Rsh(n, uint(shift))).Float64()
+	return math.Log2(f) + float64(shift)
+}
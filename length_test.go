@@ -0,0 +1,94 @@
+package diceware_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPassphrase_Length(t *testing.T) {
+	tests := []struct {
+		options  []diceware.Option
+		min, max int
+	}{
+		{[]diceware.Option{diceware.MaxLength(32)}, diceware.MinPhraseLength, 32},
+		{[]diceware.Option{diceware.MaxLength(20)}, diceware.MinPhraseLength, 20},
+		{[]diceware.Option{diceware.MaxLength(20), diceware.Extra(true)}, diceware.MinPhraseLength, 20},
+		{[]diceware.Option{diceware.MinLength(30)}, 30, math.MaxInt32},
+		{[]diceware.Option{diceware.MinLength(24), diceware.MaxLength(24)}, 24, 24},
+		{[]diceware.Option{diceware.MaxLength(12), diceware.Words(3), diceware.Validate(false)}, 0, 12},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			phrase, err := diceware.NewPassphrase(tt.options...)
+			ok(t, err)
+			l := len(phrase.String())
+			assert(t, tt.min <= l && l <= tt.max, "Expected length in [%d, %d], got %d (%q).", tt.min, tt.max, l, phrase)
+			assert(t, phrase.Entropy() >= 3*float64(diceware.BitsPerWord), "Expected entropy of at least 3 words, got %v.", phrase.Entropy())
+		}
+	}
+
+	errTests := [][]diceware.Option{
+		{diceware.MaxLength(0)},
+		{diceware.MinLength(-1)},
+		{diceware.MaxLength(10)},
+		{diceware.MinLength(20), diceware.MaxLength(19)},
+	}
+
+	for _, options := range errTests {
+		_, err := diceware.NewPassphrase(options...)
+		equals(t, diceware.ErrInvalidLength, err)
+	}
+
+	incompatibleTests := [][]diceware.Option{
+		{diceware.MaxLength(32), diceware.Checksum(true)},
+		{diceware.MaxLength(32), diceware.Unique(true)},
+		{diceware.MinLength(32), diceware.MinEditDistance(2)},
+		{diceware.MinLength(32), diceware.DistinctSounds(true)},
+	}
+
+	for _, options := range incompatibleTests {
+		_, err := diceware.NewPassphrase(options...)
+		equals(t, diceware.ErrIncompatibleOptions, err)
+	}
+}
+
+func TestPassphrase_LengthLargeMin(t *testing.T) {
+	// Words have at most 6 characters, so more words than required for the
+	// entropy are picked.
+	for _, length := range []int{80, 100, 200} {
+		phrase, err := diceware.NewPassphrase(diceware.MinLength(length))
+		ok(t, err)
+		assert(t, len(phrase.String()) >= length, "Expected at least %d characters, got %q.", length, phrase.String())
+		assert(t, phrase.Len() >= length/6, "Expected at least %d words, got %d.", length/6, phrase.Len())
+	}
+}
+
+func TestPassphrase_LengthLarge(t *testing.T) {
+	// The amount of words is found long before the maximum length, so only
+	// a few rows of the length table are calculated.
+	phrase, err := diceware.NewPassphrase(diceware.MaxLength(1000))
+	ok(t, err)
+	assert(t, phrase.Entropy() >= diceware.DefaultWords*diceware.BitsPerWord, "Expected entropy of at least %d words, got %v.", diceware.DefaultWords, phrase.Entropy())
+	for i := 0; i < 100; i++ {
+		ok(t, phrase.Regenerate())
+	}
+}
+
+func TestPassphrase_LengthEntropy(t *testing.T) {
+	// No single word of up to 4 characters provides 13 bits, so two words
+	// are picked. The list contains 51 words with 1, 1190 with 2 and 839 with
+	// 3 characters.
+	phrase, err := diceware.NewPassphrase(
+		diceware.MaxLength(4),
+		diceware.Words(1),
+		diceware.Validate(false),
+	)
+	ok(t, err)
+	equals(t, 2, len(strings.Fields(phrase.Humanize())))
+	expected := math.Log2(51*51 + 2*51*1190 + 2*51*839 + 1190*1190)
+	assert(t, math.Abs(phrase.Entropy()-expected) < 1e-9, "Expected entropy %v, got %v.", expected, phrase.Entropy())
+}
@@ -0,0 +1,308 @@
+package diceware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrListIntegrity is raised when a word list doesn't match its SHA-256 sum.
+var ErrListIntegrity = errors.New("diceware: word list failed integrity check")
+
+// listDir is the directory of the embedded word lists. Every list is stored as
+// a text file with one word per line, optionally compressed using gzip, e.g.
+// "diceware8k.txt.gz". The SHA-256 sums of the uncompressed files are stored in
+// the SHA256SUMS file, as written by sha256sum. Adding a list only requires
+// adding its file and sum.
+const listDir = "lists"
+
+//go:embed lists
+var listFiles embed.FS
+
+// A WordList is a list of words passphrases are built from. The words are
+// decoded and verified on first use.
+type WordList struct {
+	name        string
+	file        string
+	once        sync.Once
+	words       []string
+	fingerprint string
+
+	lengthsOnce sync.Once
+	lengths     [][]int64
+}
+
+// Diceware8k is the computer-optimized diceware8k list. It contains 8192 words.
+// Ref: http://world.std.com/%7Ereinhold/dicewarefaq.html#diceware8k
+var Diceware8k = &WordList{
+	name: "diceware8k",
+	file: "diceware8k.txt.gz",
+}
+
+// diceware8k returns the words of the Diceware8k list.
+func diceware8k() []string {
+	return Diceware8k.load()
+}
+
+// List is an Option that defines the word list the words of the passphrase are
+// picked from. It defaults to Diceware8k. The entropy and the Metadata of the
+// passphrase are based on the list. ErrInvalidWordList is returned if the list
+// is nil or contains less than two words.
+//
+// The checksum word is always picked from the Diceware8k list, so the List
+// Option can't be combined with the Checksum Option.
+func List(l *WordList) Option {
+	return func(p *Passphrase) error { return p.setList(l) }
+}
+func (p *Passphrase) setList(l *WordList) error {
+	if l == nil || l.Len() < 2 {
+		return ErrInvalidWordList
+	}
+	p.list = l
+	return nil
+}
+
+// extras are the characters which can be added to a passphrase as an extra.
+var extras = []string{
+	"~", "!", "#", "$", "%", "^",
+	"&", "*", "(", ")", "-", "=",
+	"+", "[", "]", "\\", "{", "}",
+	":", ";", "\"", "'", "<", ">",
+	"?", "/", "0", "1", "2", "3",
+	"4", "5", "6", "7", "8", "9",
+}
+
+var (
+	listsOnce sync.Once
+	lists     []*WordList
+)
+
+// Lists returns all embedded word lists, sorted by name.
+func Lists() []*WordList {
+	listsOnce.Do(func() {
+		entries, _ := fs.ReadDir(listFiles, listDir)
+		for _, e := range entries {
+			file := strings.TrimSuffix(e.Name(), ".gz")
+			if !strings.HasSuffix(file, ".txt") {
+				continue
+			}
+			switch name := strings.TrimSuffix(file, ".txt"); name {
+			case Diceware8k.name:
+				lists = append(lists, Diceware8k)
+			default:
+				lists = append(lists, &WordList{name: name, file: e.Name()})
+			}
+		}
+		sort.Slice(lists, func(i, j int) bool { return lists[i].name < lists[j].name })
+	})
+	return lists
+}
+
+// LookupList returns the embedded word list with the given name. It returns
+// nil if there is no such list.
+func LookupList(name string) *WordList {
+	for _, l := range Lists() {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// load decodes the words of the list once. Since the lists are embedded, a
+// list which can't be decoded or verified is a programming error and load
+// panics.
+func (l *WordList) load() []string {
+	l.once.Do(func() {
+		words, err := readList(l.file)
+		if err != nil {
+			panic(fmt.Errorf("diceware: loading word list %q: %w", l.name, err))
+		}
+		l.words, l.fingerprint = words, fingerprint(words)
+	})
+	return l.words
+}
+
+// readList reads, decompresses and verifies the embedded list file.
+func readList(file string) ([]string, error) {
+	b, err := listFiles.ReadFile(path.Join(listDir, file))
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(file, ".gz") {
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+
+	want, err := listSum(strings.TrimSuffix(file, ".gz"))
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(b); hex.EncodeToString(sum[:]) != want {
+		return nil, ErrListIntegrity
+	}
+	words := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	return words, checkWords(words)
+}
+
+// listSum returns the SHA-256 sum of the given list file as stored in the
+// SHA256SUMS file.
+func listSum(file string) (string, error) {
+	f, err := listFiles.Open(path.Join(listDir, "SHA256SUMS"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == file {
+			return fields[0], nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", ErrListIntegrity
+}
+
+// Name returns the identifier of the word list.
+func (l *WordList) Name() string {
+	return l.name
+}
+
+// Len returns the amount of words in the list.
+func (l *WordList) Len() int {
+	return len(l.load())
+}
+
+// Word returns the word at the given index.
+func (l *WordList) Word(i int) string {
+	return l.load()[i]
+}
+
+// DiceCode returns the dice roll which selects the word at the given index.
+// ErrInvalidIndex is returned if i is out of range.
+//
+// For lists with a power of six words, like the original 7776 word list, every
+// digit is the result of a six-sided die, e.g. "11111" to "66666". For lists
+// with a power of two words, every digit is the result of a four-sided die (or
+// a six-sided die where 5 and 6 are re-rolled) which selects two bits of the
+// index. If the amount of bits is odd, a leading coin flip ("H" or "T") selects
+// the most significant bit, e.g. "H111111" to "T444444" for the diceware8k
+// list. For other lists, the index is returned.
+func (l *WordList) DiceCode(i int) (string, error) {
+	if i < 0 || i >= len(l.load()) {
+		return "", ErrInvalidIndex
+	}
+	return l.diceCode(i), nil
+}
+
+// Index returns the index of the word selected by the dice roll as returned by
+// DiceCode. ErrInvalidDiceCode is returned if the code is malformed or doesn't
+// select a word of the list.
+func (l *WordList) Index(code string) (int, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	n := len(l.load())
+	switch {
+	case isPowerOf(n, 6):
+		return parseCode(code, n, 6)
+	case isPowerOf(n, 2):
+		if bits := bitLen(n); bits%2 == 0 {
+			return parseCode(code, n, 4)
+		}
+		if code == "" {
+			return 0, ErrInvalidDiceCode
+		}
+		i, err := parseCode(code[1:], n/2, 4)
+		switch code[0] {
+		case 'H':
+			return i, err
+		case 'T':
+			return n/2 + i, err
+		}
+		return 0, ErrInvalidDiceCode
+	}
+	i, err := strconv.Atoi(code)
+	if err != nil || i < 0 || i >= n {
+		return 0, ErrInvalidDiceCode
+	}
+	return i, nil
+}
+
+// diceCode returns the dice roll which selects the word at the given index. See
+// DiceCode for the format.
+func (l *WordList) diceCode(i int) string {
+	n := len(l.load())
+	switch {
+	case isPowerOf(n, 6):
+		return digits(i, n, 6, "")
+	case isPowerOf(n, 2):
+		bits := bitLen(n)
+		if bits%2 == 0 {
+			return digits(i, n, 4, "")
+		}
+		coin := "H"
+		if i>>uint(bits-1) == 1 {
+			coin = "T"
+		}
+		return digits(i&(n/2-1), n/2, 4, coin)
+	}
+	return strconv.Itoa(i)
+}
+
+// digits formats i in the given base with as many digits as needed for n
+// values, using 1 as the lowest digit.
+func digits(i, n, base int, prefix string) string {
+	var d []byte
+	for m := 1; m < n; m *= base {
+		d = append([]byte{byte('1' + i%base)}, d...)
+		i /= base
+	}
+	return prefix + string(d)
+}
+
+// parseCode parses the digits of a dice code selecting one of n values, as
+// formatted by digits.
+func parseCode(code string, n, base int) (int, error) {
+	if len(code) != len(digits(0, n, base, "")) {
+		return 0, ErrInvalidDiceCode
+	}
+	return parseDigits(code, base)
+}
+
+// bitLen returns the amount of bits needed to represent n values.
+func bitLen(n int) int {
+	bits := 0
+	for 1<<uint(bits) < n {
+		bits++
+	}
+	return bits
+}
+
+func isPowerOf(n, base int) bool {
+	if n < base {
+		return false
+	}
+	for n%base == 0 {
+		n /= base
+	}
+	return n == 1
+}
@@ -0,0 +1,72 @@
+package diceware_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestLists(t *testing.T) {
+	lists := diceware.Lists()
+	assert(t, len(lists) > 0, "Expected embedded word lists.")
+	for i := 1; i < len(lists); i++ {
+		assert(t, lists[i-1].Name() < lists[i].Name(), "Expected lists sorted by name.")
+	}
+
+	equals(t, diceware.Diceware8k, diceware.LookupList("diceware8k"))
+	assert(t, diceware.LookupList("klingon") == nil, "Expected no list for unknown name.")
+
+	for _, l := range lists {
+		assert(t, l.Len() > 0, "Expected words in list %s.", l.Name())
+		seen := make(map[string]bool, l.Len())
+		for i := 0; i < l.Len(); i++ {
+			word := l.Word(i)
+			assert(t, word != "" && !seen[word], "Expected unique, non-empty words in list %s, got %q.", l.Name(), word)
+			seen[word] = true
+		}
+	}
+	equals(t, 8192, diceware.Diceware8k.Len())
+	equals(t, "a", diceware.Diceware8k.Word(0))
+	equals(t, "@", diceware.Diceware8k.Word(8191))
+}
+
+func TestPassphrase_List(t *testing.T) {
+	words := []string{
+		"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel",
+		"india", "juliett", "kilo", "lima", "mike", "november", "oscar", "papa",
+	}
+	l, err := diceware.ReadWordList("nato", strings.NewReader(strings.Join(words, "\n")))
+	ok(t, err)
+
+	phrase, err := diceware.NewPassphrase(diceware.List(l), diceware.Words(8), diceware.Validate(false))
+	ok(t, err)
+	equals(t, 8*math.Log2(16), phrase.Entropy())
+	md := phrase.Metadata()
+	equals(t, "nato", md.List)
+	equals(t, l.Fingerprint(), md.Fingerprint)
+	for _, w := range md.Words {
+		equals(t, l.Word(w.Index), w.Word)
+		dice, err := l.DiceCode(w.Index)
+		ok(t, err)
+		equals(t, dice, w.Dice)
+	}
+	equals(t, "nato", phrase.Config().List)
+
+	// Unique words are picked from the list as well.
+	phrase, err = diceware.NewPassphrase(diceware.List(l), diceware.Words(16), diceware.Unique(true), diceware.Validate(false))
+	ok(t, err)
+	seen := make(map[string]bool)
+	for _, word := range phrase.Words() {
+		assert(t, !seen[word], "Word %q appears more than once.", word)
+		seen[word] = true
+	}
+	equals(t, 16, len(seen))
+
+	_, err = diceware.NewPassphrase(diceware.List(nil))
+	equals(t, diceware.ErrInvalidWordList, err)
+
+	_, err = diceware.NewPassphrase(diceware.List(l), diceware.Checksum(true))
+	equals(t, diceware.ErrIncompatibleOptions, err)
+}
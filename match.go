@@ -0,0 +1,259 @@
+package diceware
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// A Correction describes a change Correct made to the input in order to map it
+// to words of the word list.
+type Correction struct {
+	// Position is the index of the first word the correction resulted in.
+	Position int `json:"position"`
+
+	// Input is the token as it was typed (after case folding).
+	Input string `json:"input"`
+
+	// Words are the words the token was corrected to. A single token can be
+	// corrected to multiple words if the separator between them was missing.
+	Words []string `json:"words"`
+}
+
+var (
+	alphabetOnce sync.Once
+	alphabet     []rune
+	maxWordLen   int
+)
+
+// listAlphabet returns all characters used by words of the standard word list.
+// It also determines the length of the longest word.
+func listAlphabet() []rune {
+	alphabetOnce.Do(func() {
+		set := make(map[rune]bool)
+		for _, word := range diceware8k() {
+			for _, r := range word {
+				set[r] = true
+			}
+			if len(word) > maxWordLen {
+				maxWordLen = len(word)
+			}
+		}
+		for r := range set {
+			alphabet = append(alphabet, r)
+		}
+		sort.Slice(alphabet, func(i, j int) bool { return alphabet[i] < alphabet[j] })
+	})
+	return alphabet
+}
+
+// isSeparator reports whether r separates words. Besides whitespace, this
+// covers characters which are neither part of any word nor an extra. Note that
+// "-" and "." are NOT separators as they are part of the word list.
+func isSeparator(r rune) bool {
+	switch {
+	case unicode.IsSpace(r), r == ',', r == '_', r == '|':
+		return true
+	case r > unicode.MaxASCII && (unicode.Is(unicode.Pd, r) || unicode.Is(unicode.Zs, r)):
+		return true
+	}
+	return false
+}
+
+// isExtra reports whether s is one of the extra characters.
+func isExtra(s string) bool {
+	for _, extra := range extras {
+		if s == extra {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize canonicalizes a typed passphrase. It folds the input to lower case
+// and splits it into words at any kind of whitespace, non-ASCII dash and the
+// characters ",", "_" and "|". The result can be joined by a single whitespace
+// to compare it with the output of Humanize.
+//
+// The words of the list are ASCII only, so Normalize doesn't apply Unicode
+// normalization forms: a non-ASCII word can't be matched in any form.
+func Normalize(input string) []string {
+	return strings.FieldsFunc(strings.ToLower(input), isSeparator)
+}
+
+// Correct normalizes the input like Normalize does and maps every token that
+// is not a word of the list (optionally followed by an extra) back to the list:
+//
+//   - A token which consists of list words joined by "-" or "." is split at
+//     these separators, since they are offered by the command line tool.
+//     Splits into plain words are preferred over splits into words with an
+//     extra, and the split with the fewest words is chosen.
+//   - A token which is a single edit (insertion, deletion, substitution or
+//     transposition of adjacent characters) away from exactly one word is
+//     replaced by that word.
+//   - A token which is too long to be a single word with an extra and a typo
+//     is split into list words, because the separator between them is
+//     probably missing. The split with the fewest words is chosen.
+//
+// All changes are reported as Corrections. ErrUnknownWord is returned if a
+// token can't be corrected unambiguously.
+func Correct(input string) ([]string, []Correction, error) {
+	var (
+		words       []string
+		corrections []Correction
+	)
+	for _, token := range Normalize(input) {
+		if isListWord(token) {
+			words = append(words, token)
+			continue
+		}
+		corrected := correctToken(token)
+		if corrected == nil {
+			return nil, nil, ErrUnknownWord
+		}
+		corrections = append(corrections, Correction{
+			Position: len(words),
+			Input:    token,
+			Words:    corrected,
+		})
+		words = append(words, corrected...)
+	}
+	return words, corrections, nil
+}
+
+// isListWord reports whether the token is a word of the list, optionally
+// followed by an extra.
+func isListWord(token string) bool {
+	if _, ok := lookupWord(token); ok {
+		return true
+	}
+	if n := len(token); n > 1 && isExtra(token[n-1:]) {
+		_, ok := lookupWord(token[:n-1])
+		return ok
+	}
+	return false
+}
+
+// correctToken tries to correct a single token. It returns nil if it fails.
+func correctToken(token string) []string {
+	if words := splitSeparated(token, isPlainWord); words != nil {
+		return words
+	}
+	if words := splitSeparated(token, isListWord); words != nil {
+		return words
+	}
+	if word, ok := correctEdit(token); ok {
+		return []string{word}
+	}
+	if n := len(token); n > 1 && isExtra(token[n-1:]) {
+		if word, ok := correctEdit(token[:n-1]); ok {
+			return []string{word + token[n-1:]}
+		}
+	}
+	if len(token) > maxWordLen+2 {
+		return segment(token)
+	}
+	return nil
+}
+
+// correctEdit returns the only word of the list which is a single edit away
+// from the token.
+func correctEdit(token string) (string, bool) {
+	candidates := make(map[string]bool)
+	check := func(s string) {
+		if _, ok := lookupWord(s); ok {
+			candidates[s] = true
+		}
+	}
+
+	r := []rune(token)
+	for i := 0; i <= len(r); i++ {
+		// Deletion and transposition.
+		if i < len(r) {
+			check(string(r[:i]) + string(r[i+1:]))
+		}
+		if i+1 < len(r) {
+			check(string(r[:i]) + string(r[i+1]) + string(r[i]) + string(r[i+2:]))
+		}
+
+		// Insertion and substitution.
+		for _, c := range listAlphabet() {
+			check(string(r[:i]) + string(c) + string(r[i:]))
+			if i < len(r) && c != r[i] {
+				check(string(r[:i]) + string(c) + string(r[i+1:]))
+			}
+		}
+	}
+
+	if len(candidates) != 1 {
+		return "", false
+	}
+	for word := range candidates {
+		return word, true
+	}
+	return "", false
+}
+
+// splitSeparated splits the token at "-" and "." into the smallest amount of
+// parts which are accepted by isWord. The separators are removed, while words
+// of the list containing them, like "ph.d", are kept. It returns nil if the
+// token can't be split into at least two parts.
+func splitSeparated(token string, isWord func(string) bool) []string {
+	// best[i] holds the best split of token[i:].
+	best := make([][]string, len(token)+1)
+	best[len(token)] = []string{}
+	for i := len(token) - 1; i >= 0; i-- {
+		for j := len(token); j > i; j-- {
+			// A part ends at the end of the token or at a separator which is
+			// followed by another part.
+			next := j
+			if j < len(token) {
+				if j == len(token)-1 || (token[j] != '-' && token[j] != '.') {
+					continue
+				}
+				next = j + 1
+			}
+			if best[next] == nil || !isWord(token[i:j]) {
+				continue
+			}
+			if best[i] == nil || len(best[next])+1 < len(best[i]) {
+				best[i] = append([]string{token[i:j]}, best[next]...)
+			}
+		}
+	}
+	if len(best[0]) < 2 {
+		return nil
+	}
+	return best[0]
+}
+
+// isPlainWord reports whether the token is a word of the list without an
+// extra.
+func isPlainWord(token string) bool {
+	_, ok := lookupWord(token)
+	return ok
+}
+
+// segment splits the token into the smallest amount of list words. If multiple
+// splits with the same amount of words exist, longer leading words are
+// preferred. It returns nil if the token can't be split.
+func segment(token string) []string {
+	// best[i] holds the best split of token[i:].
+	best := make([][]string, len(token)+1)
+	best[len(token)] = []string{}
+	for i := len(token) - 1; i >= 0; i-- {
+		for j := len(token); j > i; j-- {
+			if best[j] == nil || !isListWord(token[i:j]) {
+				continue
+			}
+			if best[i] == nil || len(best[j])+1 < len(best[i]) {
+				best[i] = append([]string{token[i:j]}, best[j]...)
+			}
+		}
+	}
+	if len(best[0]) < 2 {
+		return nil
+	}
+	return best[0]
+}
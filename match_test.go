@@ -0,0 +1,63 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"lofty geese borne", []string{"lofty", "geese", "borne"}},
+		{"  Lofty\tGEESE borne ", []string{"lofty", "geese", "borne"}},
+		{"lofty_geese,borne|covet", []string{"lofty", "geese", "borne", "covet"}},
+		{"lofty–geese", []string{"lofty", "geese"}},
+		{"a&p st. ph.d", []string{"a&p", "st.", "ph.d"}},
+	}
+
+	for _, tt := range tests {
+		equals(t, tt.expected, diceware.Normalize(tt.input))
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	tests := []struct {
+		input       string
+		expected    []string
+		corrections []diceware.Correction
+		expectedErr error
+	}{
+		{"lofty geese", []string{"lofty", "geese"}, nil, nil},
+		{"lofty geese%", []string{"lofty", "geese%"}, nil, nil},
+		{"lofty geesee", []string{"lofty", "geese"}, []diceware.Correction{{1, "geesee", []string{"geese"}}}, nil},
+		{"lofty covett%", []string{"lofty", "covet%"}, []diceware.Correction{{1, "covett%", []string{"covet%"}}}, nil},
+		{"lotfy geese", []string{"lofty", "geese"}, []diceware.Correction{{0, "lotfy", []string{"lofty"}}}, nil},
+		{"loftygeese", []string{"lofty", "geese"}, []diceware.Correction{{0, "loftygeese", []string{"lofty", "geese"}}}, nil},
+		{"lofty-geese-borne", []string{"lofty", "geese", "borne"}, []diceware.Correction{{0, "lofty-geese-borne", []string{"lofty", "geese", "borne"}}}, nil},
+		{"lofty.geese.borne", []string{"lofty", "geese", "borne"}, []diceware.Correction{{0, "lofty.geese.borne", []string{"lofty", "geese", "borne"}}}, nil},
+		{"lofty-geese%-borne", []string{"lofty", "geese%", "borne"}, []diceware.Correction{{0, "lofty-geese%-borne", []string{"lofty", "geese%", "borne"}}}, nil},
+		{"ph.d.lofty", []string{"ph.d", "lofty"}, []diceware.Correction{{0, "ph.d.lofty", []string{"ph.d", "lofty"}}}, nil},
+		{"lofty gese", nil, nil, diceware.ErrUnknownWord},
+		{"lofty ñandú", nil, nil, diceware.ErrUnknownWord},
+	}
+
+	for _, tt := range tests {
+		words, corrections, err := diceware.Correct(tt.input)
+		equals(t, tt.expectedErr, err)
+		equals(t, tt.expected, words)
+		equals(t, tt.corrections, corrections)
+	}
+
+	// A passphrase with a checksum word survives the "-" and "." separators.
+	words := diceware.EncodeChecksum([]byte("lofty geese"))
+	for _, sep := range []string{"-", "."} {
+		corrected, _, err := diceware.Correct(strings.Join(words, sep))
+		ok(t, err)
+		equals(t, words, corrected)
+		ok(t, diceware.VerifyChecksum(corrected))
+	}
+}
@@ -0,0 +1,54 @@
+package diceware
+
+// WordMetadata describes a single word of a passphrase.
+type WordMetadata struct {
+	// Word is the word as it appears in the passphrase, including the extra.
+	Word string `json:"word"`
+	// Index is the index of the word in the word list.
+	Index int `json:"index"`
+	// Dice is the dice roll which selects the word, see WriteTextSheet.
+	Dice string `json:"dice"`
+	// Extra is the extra added to the word. It is empty if the word doesn't
+	// carry the extra.
+	Extra string `json:"extra,omitempty"`
+	// ExtraPosition is the byte offset of the extra in Word. It is -1 if the
+	// word doesn't carry the extra.
+	ExtraPosition int `json:"extra_position"`
+	// Checksum is true for the checksum word.
+	Checksum bool `json:"checksum"`
+}
+
+// Metadata describes a passphrase and the word list it is built from, e.g. to
+// render the words of a passphrase as a numbered grid.
+type Metadata struct {
+	// List is the name of the word list.
+	List string `json:"list"`
+	// Fingerprint is the fingerprint of the word list, see
+	// WordList.Fingerprint.
+	Fingerprint string `json:"fingerprint"`
+	// Words describes every word of the passphrase in order.
+	Words []WordMetadata `json:"words"`
+}
+
+// Metadata returns the metadata of the passphrase.
+func (p Passphrase) Metadata() Metadata {
+	words := make([]WordMetadata, len(p.words))
+	for i, word := range p.words {
+		words[i] = WordMetadata{
+			Word:          word,
+			Index:         int(p.ids[i]),
+			Dice:          p.list.diceCode(int(p.ids[i])),
+			ExtraPosition: -1,
+			Checksum:      p.checksum && i == len(p.words)-1,
+		}
+		if p.extra && i == p.extraIndex {
+			words[i].Extra = word[len(word)-1:]
+			words[i].ExtraPosition = len(word) - 1
+		}
+	}
+	return Metadata{
+		List:        p.list.Name(),
+		Fingerprint: p.list.Fingerprint(),
+		Words:       words,
+	}
+}
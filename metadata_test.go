@@ -0,0 +1,51 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPassphrase_Words(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(diceware.Extra(true), diceware.Checksum(true), diceware.Validate(false))
+	ok(t, err)
+	words := phrase.Words()
+	equals(t, diceware.DefaultWords+1, phrase.Len())
+	equals(t, phrase.Len(), len(words))
+	equals(t, phrase.String(), strings.Join(words, ""))
+	equals(t, phrase.Humanize(), strings.Join(words, " "))
+
+	// The returned words are a copy.
+	words[0] = ""
+	assert(t, phrase.Words()[0] != "", "Expected Words() to return a copy.")
+}
+
+func TestPassphrase_Metadata(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		phrase, err := diceware.NewPassphrase(diceware.Extra(true), diceware.Checksum(true), diceware.Validate(false))
+		ok(t, err)
+		md := phrase.Metadata()
+		equals(t, "diceware8k", md.List)
+		equals(t, phrase.Len(), len(md.Words))
+
+		extras := 0
+		for j, w := range md.Words {
+			equals(t, phrase.Words()[j], w.Word)
+			equals(t, j == phrase.Len()-1, w.Checksum)
+			assert(t, len(w.Dice) == 7 && strings.ContainsAny(w.Dice[:1], "HT"), "Unexpected dice code %q.", w.Dice)
+
+			word := w.Word
+			if w.Extra != "" {
+				extras++
+				equals(t, w.Extra, word[w.ExtraPosition:w.ExtraPosition+len(w.Extra)])
+				word = word[:w.ExtraPosition] + word[w.ExtraPosition+len(w.Extra):]
+			} else {
+				equals(t, -1, w.ExtraPosition)
+			}
+			equals(t, diceware.Diceware8k.Word(w.Index), word)
+		}
+		equals(t, 1, extras)
+		assert(t, md.Words[len(md.Words)-1].Extra == "", "Expected no extra on the checksum word.")
+	}
+}
@@ -2,13 +2,18 @@ package diceware
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
+	"io"
 	"math"
-	"math/big"
 	"strings"
 )
 
 const (
+	// DefaultChecksum is the default value for the checksum word. A checksum
+	// word can be appended to a passphrase to detect typos.
+	DefaultChecksum = false
+
 	// DefaultExtra is the default value for the extra character. An extra can
 	// be added to a passphrase to increase security without adding another
 	// word. It isn't required by default.
@@ -19,13 +24,19 @@ const (
 	// Ref: https://diceware.blogspot.de/2014/03/time-to-add-word.html
 	DefaultWords = 6
 
+	// DefaultUnique is the default value for sampling words without
+	// replacement.
+	DefaultUnique = false
+
 	// DefaultValidate is the default value for the validation step.
 	DefaultValidate = true
 
 	// MinPhraseLength is the smallest amount of characters allowed in a
 	// passphrase to pass the validation. Since generation is random, there is a
 	// very small chance of getting a passphrase which has less than 17
-	// characters in total which IS NOT considered save.
+	// characters in total which IS NOT considered save. If length bounds are
+	// set (see MaxLength), passphrases are picked from those with at least
+	// this length instead of failing validation.
 	// Ref: http://world.std.com/~reinhold/dicewarefaq.html#14characters
 	MinPhraseLength = 17
 
@@ -43,12 +54,28 @@ var (
 	// ErrValidationFailed is raised when the generated passphrase doesn't met
 	// the default security standards.
 	ErrValidationFailed = errors.New("diceware: invalid passphrase was generated")
+
+	// ErrInvalidSource is raised when the specified source of randomness is
+	// nil.
+	ErrInvalidSource = errors.New("diceware: source is invalid")
 )
 
 // An Option serves as a functional parameter which can be used to costumize the
 // generation of the passphrase.
 type Option func(p *Passphrase) error
 
+// Checksum is an Option that specifies whaether a checksum word will be
+// appended to the passphrase or not. The checksum word is calculated from the
+// preceding words as described by ChecksumWord and can be verified using
+// VerifyChecksum.
+func Checksum(checksum bool) Option {
+	return func(p *Passphrase) error { return p.setChecksum(checksum) }
+}
+func (p *Passphrase) setChecksum(checksum bool) error {
+	p.checksum = checksum
+	return nil
+}
+
 // Extra is an Option that specifies whaether an extra will be added to the
 // passphrase or not.
 func Extra(extra bool) Option {
@@ -56,6 +83,33 @@ func Extra(extra bool) Option {
 }
 func (p *Passphrase) setExtra(extra bool) error {
 	p.extra = extra
+	p.extraSet = true
+	return nil
+}
+
+// Unique is an Option that specifies whaether words are sampled without
+// replacement, so no word appears more than once in the passphrase. The
+// entropy is reduced accordingly.
+func Unique(unique bool) Option {
+	return func(p *Passphrase) error { return p.setUnique(unique) }
+}
+func (p *Passphrase) setUnique(unique bool) error {
+	p.unique = unique
+	return nil
+}
+
+// Source is an Option that defines the source of randomness words are picked
+// with. It defaults to crypto/rand.Reader. Only cryptographically secure
+// sources must be used, otherwise passphrases can be guessed. SelfTest can
+// detect sources which are obviously broken. Derive replaces the source.
+func Source(r io.Reader) Option {
+	return func(p *Passphrase) error { return p.setSource(r) }
+}
+func (p *Passphrase) setSource(r io.Reader) error {
+	if r == nil {
+		return ErrInvalidSource
+	}
+	p.source = r
 	return nil
 }
 
@@ -76,7 +130,7 @@ func Words(words int) Option {
 }
 func (p *Passphrase) setWords(words int) error {
 	if words < MinWords {
-		return ErrInvalidWordCount
+		return &WordCountError{Got: words, Min: MinWords}
 	}
 	p.wordCount = words
 	return nil
@@ -86,21 +140,61 @@ func (p *Passphrase) setWords(words int) error {
 // that are randomly picked from a list of words.
 // Ref: http://world.std.com/~reinhold/diceware.html
 type Passphrase struct {
-	extra     bool
-	validate  bool
-	wordCount int
-	words     []string
+	boundedBits     float64
+	boundedWords    int
+	checksum        bool
+	derivation      *derivation
+	distinctSounds  bool
+	extra           bool
+	extraIndex      int
+	extraSet        bool
+	ids             []int64
+	lengths         lengthTable
+	list            *WordList
+	listFingerprint string
+	maxLength       int
+	minEditDistance int
+	minEntropy      float64
+	minLength       int
+	source          io.Reader
+	unique          bool
+	validate        bool
+	wordCount       int
+	words           []string
 }
 
 // NewPassphrase defines, generates, validates and returns a new diceware
 // passphrase.
 func NewPassphrase(options ...Option) (*Passphrase, error) {
+	p, err := newPassphrase(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate passphrase.
+	if err := p.Regenerate(); err != nil {
+		return nil, err
+	}
+
+	// Return passphrase.
+	return p, nil
+}
+
+// newPassphrase creates a passphrase with default settings and applies the
+// supplied options. The passphrase is not generated.
+func newPassphrase(options []Option) (*Passphrase, error) {
 	// Create passphrase with default settings.
 	p := &Passphrase{
-		extra:     DefaultExtra,
-		validate:  DefaultValidate,
-		wordCount: DefaultWords,
-		words:     nil,
+		checksum:        DefaultChecksum,
+		distinctSounds:  DefaultDistinctSounds,
+		extra:           DefaultExtra,
+		list:            Diceware8k,
+		minEditDistance: DefaultMinEditDistance,
+		source:          rand.Reader,
+		unique:          DefaultUnique,
+		validate:        DefaultValidate,
+		wordCount:       DefaultWords,
+		words:           nil,
 	}
 
 	// Apply supplied options.
@@ -110,15 +204,50 @@ func NewPassphrase(options ...Option) (*Passphrase, error) {
 		}
 	}
 
-	// Generate passphrase.
-	if err := p.Regenerate(); err != nil {
+	// The list must match the fingerprint of a configuration.
+	if p.listFingerprint != "" && p.list.Verify(p.listFingerprint) != nil {
+		return nil, ErrFingerprintMismatch
+	}
+
+	// The checksum word is picked from the Diceware8k list.
+	if p.checksum && p.list != Diceware8k {
+		return nil, ErrIncompatibleOptions
+	}
+
+	// Choose the amount of words for the target entropy.
+	if err := p.resolveMinEntropy(); err != nil {
+		return nil, err
+	}
+
+	// Choose the amount of words within the length bounds.
+	if err := p.resolveLengthBounds(); err != nil {
 		return nil, err
 	}
 
-	// Return passphrase.
 	return p, nil
 }
 
+// Entropy returns the entropy of the passphrase in bits. A checksum word
+// doesn't add any entropy and is therefore not taken into account.
+//
+// If words are excluded by the Unique, MinEditDistance or DistinctSounds
+// Options, every word is assumed to exclude as many words as the word of the
+// list which is similar to the most other words. The result is a lower bound
+// of the min-entropy of the passphrase.
+func (p Passphrase) Entropy() float64 {
+	words := p.wordCount
+	entropy := float64(p.wordCount) * math.Log2(float64(p.list.Len()))
+	if p.bounded() {
+		words, entropy = p.boundedWords, p.boundedBits
+	} else if excluded := p.excluded(); excluded > 0 {
+		entropy = exclusionBits(p.list.Len(), p.wordCount, excluded)
+	}
+	if p.extra {
+		entropy += math.Log2(float64(len(extras))) + math.Log2(float64(words))
+	}
+	return entropy
+}
+
 // Humanize will return a human readable string which has a whitspace between
 // each word.
 func (p Passphrase) Humanize() string {
@@ -129,6 +258,21 @@ func (p Passphrase) Humanize() string {
 	return strings.TrimSpace(str)
 }
 
+// Words returns the words of the passphrase, including the extra and the
+// checksum word if present. Other than splitting the result of Humanize, this
+// is safe for extras which are whitespace.
+func (p Passphrase) Words() []string {
+	words := make([]string, len(p.words))
+	copy(words, p.words)
+	return words
+}
+
+// Len returns the amount of words of the passphrase, including the checksum
+// word if present.
+func (p Passphrase) Len() int {
+	return len(p.words)
+}
+
 // String implements the Stringer interface.
 func (p Passphrase) String() string {
 	str := ""
@@ -147,54 +291,183 @@ func (p *Passphrase) Regenerate() error {
 	}
 
 	// Validate passphrase.
-	if p.validate && !p.Validate() {
-		return ErrValidationFailed
+	if p.validate {
+		if v := p.violations(); len(v) > 0 {
+			return &ValidationError{Rules: v}
+		}
 	}
 
 	return nil
 }
 
 // Validate verifies that the passphrase mets certain standards like a secure
-// length and word count.
+// length and word count. A checksum word is not taken into account. If length
+// bounds are set, they replace the MinPhraseLength check.
 func (p *Passphrase) Validate() bool {
-	return MinPhraseLength <= len(p.String()) && DefaultWords <= p.wordCount
+	return len(p.violations()) == 0
+}
+
+// violations returns the rules the passphrase doesn't met.
+func (p *Passphrase) violations() []Violation {
+	words := p.words
+	if p.checksum {
+		words = words[:len(words)-1]
+	}
+	length := 0
+	for _, word := range words {
+		length += len(word)
+	}
+	minLength := MinPhraseLength
+	if p.minLength > 0 {
+		minLength = p.minLength
+	}
+	var v []Violation
+	if length < minLength {
+		v = append(v, Violation{RuleMinLength, length, minLength})
+	}
+	if p.maxLength > 0 && length > p.maxLength {
+		v = append(v, Violation{RuleMaxLength, length, p.maxLength})
+	}
+	if words := p.wordsPicked(); words < DefaultWords {
+		v = append(v, Violation{RuleMinWords, words, DefaultWords})
+	}
+	return v
+}
+
+// VerifyChecksum verifies the checksum word of the passphrase. It returns
+// ErrChecksumMismatch if the passphrase was generated without a checksum word.
+func (p *Passphrase) VerifyChecksum() error {
+	if !p.checksum {
+		return ErrChecksumMismatch
+	}
+	return VerifyChecksum(p.words)
 }
 
 func (p *Passphrase) generate() error {
-	p.words = make([]string, p.wordCount)
-	for i := 0; i < p.wordCount; i++ {
-		id, err := generateID(math.MaxInt64)
-		if err != nil {
-			return err
-		}
-		p.words[i] = getWord(id)
+	generateIDs := p.generateIDs
+	if p.bounded() {
+		generateIDs = p.generateBounded
+	}
+	ids, err := generateIDs()
+	if err != nil {
+		return err
+	}
+	p.ids = ids
+	p.words = make([]string, len(ids))
+	for i, id := range ids {
+		p.words[i] = p.list.Word(int(id))
 	}
 
+	p.extraIndex = -1
 	if p.extra {
-		id, err := generateID(36)
+		id, err := generateID(p.source, int64(len(extras)))
 		if err != nil {
 			return err
 		}
-		wc, err := generateID(int64(len(p.words)))
+		wc, err := generateID(p.source, int64(len(p.words)))
 		if err != nil {
 			return err
 		}
 		p.words[wc] += extras[id]
+		p.extraIndex = int(wc)
+	}
+
+	if p.checksum {
+		word := ChecksumWord(p.words)
+		id, _ := wordID(word)
+		p.ids = append(p.ids, int64(id))
+		p.words = append(p.words, word)
 	}
 
 	return nil
 }
 
-func generateID(from int64) (int64, error) {
-	n, err := rand.Int(rand.Reader, big.NewInt(from))
-	if err != nil {
-		return 0, err
+// similarity returns the definition of similar words of the passphrase.
+func (p Passphrase) similarity() similarity {
+	return similarity{
+		list:            p.list,
+		minEditDistance: p.minEditDistance,
+		distinctSounds:  p.distinctSounds,
 	}
-	return n.Int64(), nil
 }
 
-// getWord retrieves the requested word from the standard word list. The
-// function also handles integers that are overflowing the max id of 8191.
-func getWord(id int64) string {
-	return diceware8k[id&8191]
+// excluded returns the maximum amount of words a single word of the passphrase
+// excludes from the remaining words, including itself. It returns 0 if words
+// are picked independently.
+func (p Passphrase) excluded() int {
+	if p.distinctSounds || p.minEditDistance > 1 {
+		return p.similarity().maxSimilar()
+	}
+	if p.unique {
+		return 1
+	}
+	return 0
+}
+
+// generateIDs returns the IDs of the words of the passphrase.
+func (p *Passphrase) generateIDs() ([]int64, error) {
+	excluded := p.excluded()
+	if excluded > 0 && p.list.Len()-(p.wordCount-1)*excluded < 1 {
+		max := (p.list.Len()-1)/excluded + 1
+		return nil, &WordCountError{Got: p.wordCount, Min: MinWords, Max: max}
+	}
+	switch {
+	case excluded == 1:
+		return sampleDistinct(p.source, p.list.Len(), p.wordCount)
+	case excluded > 1:
+		return p.sampleDissimilar()
+	}
+
+	ids := make([]int64, p.wordCount)
+	for i := range ids {
+		id, err := generateID(p.source, int64(p.list.Len()))
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// sampleDissimilar picks words one after another. Every word is picked
+// uniformly from the words which are not similar to the words picked before.
+func (p *Passphrase) sampleDissimilar() ([]int64, error) {
+	s := p.similarity()
+	ids := make([]int64, 0, p.wordCount)
+	for len(ids) < p.wordCount {
+		id, err := generateID(p.source, int64(p.list.Len()))
+		if err != nil {
+			return nil, err
+		}
+		similar := false
+		for _, prev := range ids {
+			if s.similar(p.list.Word(int(prev)), p.list.Word(int(id))) {
+				similar = true
+				break
+			}
+		}
+		if !similar {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// generateID returns a uniformly distributed integer in [0, from) read from the
+// given source. It reads 8 bytes at a time, interprets them as a big endian
+// unsigned integer and rejects values which would introduce a modulo bias. The
+// algorithm is stable, so a deterministic source always yields the same IDs.
+// Errors of the source are wrapped.
+func generateID(r io.Reader, from int64) (int64, error) {
+	n := uint64(from)
+	limit := math.MaxUint64 - (math.MaxUint64%n+1)%n
+	var buf [8]byte
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return 0, sourceError(err)
+		}
+		if v := binary.BigEndian.Uint64(buf[:]); v <= limit {
+			return int64(v % n), nil
+		}
+	}
 }
@@ -1,7 +1,9 @@
 package diceware_test
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -30,13 +32,33 @@ func TestNewPassphrase(t *testing.T) {
 			diceware.Words(tt.words),
 			diceware.Validate(false),
 		)
-		equals(t, tt.expectedErr, err)
+		assert(t, errors.Is(err, tt.expectedErr), "Expected error %v, got %v.", tt.expectedErr, err)
 		if err == nil {
 			equals(t, tt.words, len(strings.Fields(phrase.Humanize())))
 		}
 	}
 }
 
+func TestPassphrase_Unique(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(
+		diceware.Unique(true),
+		diceware.Words(1000),
+	)
+	ok(t, err)
+
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(phrase.Humanize()) {
+		assert(t, !seen[word], "Word %q appears more than once.", word)
+		seen[word] = true
+	}
+
+	_, err = diceware.NewPassphrase(
+		diceware.Unique(true),
+		diceware.Words(diceware.Diceware8k.Len()+1),
+	)
+	assert(t, errors.Is(err, diceware.ErrInvalidWordCount), "Expected invalid word count, got %v.", err)
+}
+
 func TestPassphrase_Regenerate(t *testing.T) {
 	phrase, err := diceware.NewPassphrase(
 		diceware.Validate(false),
@@ -47,6 +69,41 @@ func TestPassphrase_Regenerate(t *testing.T) {
 	assert(t, phrase.String() != phraseStr, "Expected Regenerate() to create new, unique passphrase.")
 }
 
+func TestPassphrase_Checksum(t *testing.T) {
+	phrase, err := diceware.NewPassphrase(
+		diceware.Checksum(true),
+		diceware.Extra(true),
+		diceware.Validate(false),
+	)
+	ok(t, err)
+	ok(t, phrase.VerifyChecksum())
+
+	words := strings.Fields(phrase.Humanize())
+	equals(t, diceware.DefaultWords+1, len(words))
+	ok(t, diceware.VerifyChecksum(words))
+
+	words[0], words[1] = words[1], words[0]
+	equals(t, diceware.ErrChecksumMismatch, diceware.VerifyChecksum(words))
+}
+
+func TestPassphrase_Entropy(t *testing.T) {
+	tests := []struct {
+		options []diceware.Option
+		entropy float64
+	}{
+		{[]diceware.Option{diceware.Words(6)}, 78},
+		{[]diceware.Option{diceware.Words(6), diceware.Checksum(true)}, 78},
+		{[]diceware.Option{diceware.Words(4), diceware.Extra(true)}, 52 + math.Log2(36) + 2},
+		{[]diceware.Option{diceware.Words(2), diceware.Unique(true)}, 13 + math.Log2(8191)},
+	}
+
+	for _, tt := range tests {
+		phrase, err := diceware.NewPassphrase(append(tt.options, diceware.Validate(false))...)
+		ok(t, err)
+		equals(t, tt.entropy, phrase.Entropy())
+	}
+}
+
 func BenchmarkPassphrase(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		diceware.NewPassphrase()
@@ -0,0 +1,113 @@
+package diceware
+
+import (
+	"crypto/rand"
+	"io"
+	"math"
+	"strings"
+)
+
+// RecoveryCodeSeparator is the separator used by the String method of a
+// RecoveryCode.
+const RecoveryCodeSeparator = "-"
+
+// A RecoveryCode is a short phrase which is issued as part of a set of one-time
+// codes, e.g. for account recovery.
+type RecoveryCode struct {
+	// Words are the words of the recovery code.
+	Words []string
+
+	// Entropy is the entropy of the recovery code in bits. It assumes that an
+	// attacker knows all other codes of the set, which excludes their words.
+	Entropy float64
+}
+
+// Format returns the words of the recovery code joined by the given separator.
+func (c RecoveryCode) Format(sep string) string {
+	return strings.Join(c.Words, sep)
+}
+
+// String implements the Stringer interface. It joins the words by
+// RecoveryCodeSeparator, e.g. "word-word-word".
+func (c RecoveryCode) String() string {
+	return c.Format(RecoveryCodeSeparator)
+}
+
+// RecoveryCodes generates n recovery codes of the given amount of words. No
+// word appears more than once in the whole set, so all codes are distinct.
+// A *WordCountError, reporting the amount of words of the whole set, is
+// returned if n or wordsPerCode is smaller than one or the word list doesn't
+// contain enough words for the set.
+func RecoveryCodes(n, wordsPerCode int) ([]RecoveryCode, error) {
+	if n < 1 || wordsPerCode < MinWords {
+		return nil, &WordCountError{Got: n * wordsPerCode, Min: MinWords}
+	}
+	// The amount of words is checked by division, since n*wordsPerCode might
+	// overflow.
+	if n > len(diceware8k())/wordsPerCode {
+		got := math.MaxInt
+		if n <= math.MaxInt/wordsPerCode {
+			got = n * wordsPerCode
+		}
+		return nil, &WordCountError{Got: got, Min: MinWords, Max: len(diceware8k())}
+	}
+
+	ids, err := sampleDistinct(rand.Reader, len(diceware8k()), n*wordsPerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// The words of the other codes are excluded from the words of a code.
+	entropy := permutationBits(len(diceware8k())-(n-1)*wordsPerCode, wordsPerCode)
+
+	codes := make([]RecoveryCode, n)
+	for i := range codes {
+		words := make([]string, wordsPerCode)
+		for j := range words {
+			words[j] = diceware8k()[ids[i*wordsPerCode+j]]
+		}
+		codes[i] = RecoveryCode{Words: words, Entropy: entropy}
+	}
+	return codes, nil
+}
+
+// sampleDistinct draws k distinct integers from [0, n) read from the given
+// source. It performs a partial Fisher-Yates shuffle.
+func sampleDistinct(r io.Reader, n, k int) ([]int64, error) {
+	swapped := make(map[int64]int64, k)
+	get := func(i int64) int64 {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	ids := make([]int64, k)
+	for i := int64(0); i < int64(k); i++ {
+		j, err := generateID(r, int64(n)-i)
+		if err != nil {
+			return nil, err
+		}
+		j += i
+		ids[i] = get(j)
+		swapped[j] = get(i)
+	}
+	return ids, nil
+}
+
+// permutationBits returns the binary logarithm of the amount of ordered
+// selections of k distinct items out of n.
+func permutationBits(n, k int) float64 {
+	return exclusionBits(n, k, 1)
+}
+
+// exclusionBits returns the binary logarithm of the amount of ordered
+// selections of k items out of n, where every selected item excludes at most
+// the given amount of items (including itself) from further selection.
+func exclusionBits(n, k, excluded int) float64 {
+	bits := 0.0
+	for i := 0; i < k; i++ {
+		bits += math.Log2(float64(n - i*excluded))
+	}
+	return bits
+}
@@ -0,0 +1,48 @@
+package diceware_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := diceware.RecoveryCodes(10, 3)
+	ok(t, err)
+	equals(t, 10, len(codes))
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		equals(t, 3, len(code.Words))
+		equals(t, strings.Join(code.Words, "-"), code.String())
+		equals(t, strings.Join(code.Words, " "), code.Format(" "))
+		equals(t, math.Log2(8192-27)+math.Log2(8192-28)+math.Log2(8192-29), code.Entropy)
+		for _, word := range code.Words {
+			assert(t, !seen[word], "Word %q appears more than once.", word)
+			seen[word] = true
+		}
+	}
+
+	// Every word of the list can be used exactly once.
+	codes, err = diceware.RecoveryCodes(diceware.Diceware8k.Len(), 1)
+	ok(t, err)
+	equals(t, diceware.Diceware8k.Len(), len(codes))
+
+	tests := []struct {
+		n, words int
+	}{
+		{0, 3},
+		{10, 0},
+		{diceware.Diceware8k.Len(), 2},
+		{math.MaxInt / 2, 4},
+		{math.MaxInt, 2},
+	}
+
+	for _, tt := range tests {
+		_, err := diceware.RecoveryCodes(tt.n, tt.words)
+		assert(t, errors.Is(err, diceware.ErrInvalidWordCount), "Expected invalid word count, got %v.", err)
+	}
+}
@@ -0,0 +1,170 @@
+package diceware
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidSamples is raised when the amount of samples of a self-test is
+	// smaller than one.
+	ErrInvalidSamples = errors.New("diceware: amount of samples is invalid")
+
+	// ErrSelfTestUnsupported is raised when the Options of a self-test pick
+	// words which are not uniformly distributed by design. This is the case for
+	// length bounds, MinEditDistance and DistinctSounds.
+	ErrSelfTestUnsupported = errors.New("diceware: options can't be self-tested")
+)
+
+// A SelfTestResult is the result of a chi-squared test for uniformity of a
+// distribution sampled by SelfTest.
+type SelfTestResult struct {
+	// Name is the name of the distribution.
+	Name string `json:"name"`
+	// Samples is the amount of values sampled.
+	Samples int `json:"samples"`
+	// DegreesOfFreedom is the amount of possible values minus one.
+	DegreesOfFreedom int `json:"degrees_of_freedom"`
+	// ChiSquared is the test statistic.
+	ChiSquared float64 `json:"chi_squared"`
+	// PValue is the probability of a statistic of at least ChiSquared if the
+	// values are uniformly distributed.
+	PValue float64 `json:"p_value"`
+}
+
+// Passed reports whaether the distribution is considered uniform at the given
+// significance level, e.g. 0.001.
+func (r SelfTestResult) Passed(alpha float64) bool {
+	return r.PValue >= alpha
+}
+
+// String implements the Stringer interface.
+func (r SelfTestResult) String() string {
+	return fmt.Sprintf("%s: chi-squared %.1f, %d degrees of freedom, p-value %.4f", r.Name, r.ChiSquared, r.DegreesOfFreedom, r.PValue)
+}
+
+// SelfTest generates the given amount of passphrases using the Options, e.g.
+// Source, and tests whaether the word indices, the extras and the positions of
+// the extras are uniformly distributed. An extra is always added. Validation is
+// skipped, since it rejects passphrases and therefore changes the
+// distribution.
+//
+// The tests are chi-squared tests, which need about five samples per possible
+// value. Since the diceware8k list contains 8192 words, at least 50000 words
+// should be sampled, e.g. 10000 passphrases of six words. Even a perfect
+// source fails a test with the probability of the significance level.
+func SelfTest(samples int, options ...Option) ([]SelfTestResult, error) {
+	if samples < 1 {
+		return nil, ErrInvalidSamples
+	}
+	p, err := newPassphrase(append(options[:len(options):len(options)], Extra(true)))
+	if err != nil {
+		return nil, err
+	}
+	if p.bounded() || p.excluded() > 1 {
+		return nil, ErrSelfTestUnsupported
+	}
+
+	extraIDs := make(map[string]int, len(extras))
+	for i, extra := range extras {
+		extraIDs[extra] = i
+	}
+
+	words := make([]int, p.list.Len())
+	chars := make([]int, len(extras))
+	positions := make([]int, p.wordCount)
+	for i := 0; i < samples; i++ {
+		if err := p.generate(); err != nil {
+			return nil, err
+		}
+		for _, id := range p.ids[:p.wordCount] {
+			words[id]++
+		}
+		word := p.words[p.extraIndex]
+		chars[extraIDs[word[len(word)-1:]]]++
+		positions[p.extraIndex]++
+	}
+
+	return []SelfTestResult{
+		chiSquaredTest("words", words),
+		chiSquaredTest("extras", chars),
+		chiSquaredTest("extra positions", positions),
+	}, nil
+}
+
+// chiSquaredTest tests the counts of the possible values for uniformity.
+func chiSquaredTest(name string, counts []int) SelfTestResult {
+	r := SelfTestResult{Name: name, DegreesOfFreedom: len(counts) - 1, PValue: 1}
+	for _, c := range counts {
+		r.Samples += c
+	}
+	if r.DegreesOfFreedom < 1 || r.Samples == 0 {
+		return r
+	}
+
+	expected := float64(r.Samples) / float64(len(counts))
+	for _, c := range counts {
+		d := float64(c) - expected
+		r.ChiSquared += d * d / expected
+	}
+	r.PValue = gammaQ(float64(r.DegreesOfFreedom)/2, r.ChiSquared/2)
+	return r
+}
+
+const (
+	gammaEpsilon       = 1e-15
+	gammaMaxIterations = 100000
+)
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x),
+// which is the survival function of the chi-squared distribution with 2a
+// degrees of freedom at 2x.
+// Ref: Numerical Recipes, 6.2 Incomplete Gamma Function
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lg, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lg)
+
+	if x < a+1 {
+		// Series representation of P(a, x).
+		sum := 1 / a
+		del := sum
+		for n := 1; n < gammaMaxIterations; n++ {
+			del *= x / (a + float64(n))
+			sum += del
+			if math.Abs(del) < math.Abs(sum)*gammaEpsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+
+	// Continued fraction representation of Q(a, x) using Lentz's method.
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < gammaMaxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < gammaEpsilon {
+			break
+		}
+	}
+	return prefix * h
+}
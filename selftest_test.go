@@ -0,0 +1,55 @@
+package diceware_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+// evenReader is a broken source which never sets the lowest bit.
+type evenReader struct{ r io.Reader }
+
+func (e evenReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	for i := range p[:n] {
+		p[i] &^= 1
+	}
+	return n, err
+}
+
+func TestSelfTest(t *testing.T) {
+	results, err := diceware.SelfTest(10000, diceware.Source(rand.New(rand.NewSource(1))))
+	ok(t, err)
+	equals(t, 3, len(results))
+	equals(t, 8191, results[0].DegreesOfFreedom)
+	equals(t, 10000*diceware.DefaultWords, results[0].Samples)
+	equals(t, 35, results[1].DegreesOfFreedom)
+	equals(t, diceware.DefaultWords-1, results[2].DegreesOfFreedom)
+	for _, r := range results {
+		assert(t, r.Passed(0.001), "Expected %s to pass.", r)
+	}
+
+	results, err = diceware.SelfTest(10000, diceware.Source(evenReader{rand.New(rand.NewSource(1))}))
+	ok(t, err)
+	assert(t, !results[0].Passed(0.001), "Expected %s to fail.", results[0])
+
+	results, err = diceware.SelfTest(1000, diceware.Source(bytes.NewReader(make([]byte, 1<<20))))
+	ok(t, err)
+	for _, r := range results {
+		assert(t, !r.Passed(0.001), "Expected %s to fail.", r)
+	}
+}
+
+func TestSelfTest_Invalid(t *testing.T) {
+	_, err := diceware.SelfTest(0)
+	equals(t, diceware.ErrInvalidSamples, err)
+	_, err = diceware.SelfTest(1, diceware.MaxLength(30))
+	equals(t, diceware.ErrSelfTestUnsupported, err)
+	_, err = diceware.SelfTest(1, diceware.MinEditDistance(2))
+	equals(t, diceware.ErrSelfTestUnsupported, err)
+	_, err = diceware.SelfTest(1, diceware.Source(nil))
+	equals(t, diceware.ErrInvalidSource, err)
+}
@@ -0,0 +1,218 @@
+/*
+Package server provides an HTTP API for the generation of diceware passphrases.
+*/
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/lukasmalkmus/diceware"
+	"github.com/lukasmalkmus/diceware/service"
+)
+
+const (
+	// DefaultRate is the default amount of requests a single client can
+	// perform per second.
+	DefaultRate = 1.0
+
+	// DefaultBurst is the default amount of requests a single client can
+	// perform at once.
+	DefaultBurst = 10
+
+	// List is the identifier of the word list used for generation.
+	List = "diceware8k"
+
+	// MaxWords is the largest amount of words a client can request.
+	MaxWords = service.MaxWords
+
+	// maxBodySize is the largest size of a request body in bytes.
+	maxBodySize = 1 << 10
+)
+
+// ErrInvalidRateLimit is raised when the rate or burst of the rate limit is not
+// positive.
+var ErrInvalidRateLimit = errors.New("server: rate limit is invalid")
+
+// An Option serves as a functional parameter which can be used to costumize the
+// Server.
+type Option func(s *Server) error
+
+// RateLimit is an Option that defines how many requests a single client can
+// perform per second and at once.
+func RateLimit(rate float64, burst int) Option {
+	return func(s *Server) error { return s.setRateLimit(rate, burst) }
+}
+func (s *Server) setRateLimit(rate float64, burst int) error {
+	if rate <= 0 || burst < 1 {
+		return ErrInvalidRateLimit
+	}
+	s.limiter = newLimiter(rate, burst)
+	return nil
+}
+
+// Server is an http.Handler which generates diceware passphrases. Generated
+// passphrases are never logged.
+//
+// Passphrases are requested by GET requests with the query parameters words,
+// extra, checksum and validate or by POST requests with a JSON encoded Request
+// as body. These parameters correspond to the diceware Options. Requests for
+// more than MaxWords words are rejected. The response is a JSON encoded
+// Response.
+type Server struct {
+	limiter *limiter
+}
+
+// Request holds the parameters of a passphrase generation. Unset parameters
+// fall back to the defaults of the diceware package. It is the same as the
+// request of the typed service.
+type Request = service.GenerateRequest
+
+// Response is the result of a passphrase generation.
+type Response struct {
+	Passphrase  string   `json:"passphrase"`
+	Words       []string `json:"words"`
+	Entropy     float64  `json:"entropy"`
+	List        string   `json:"list"`
+	Fingerprint string   `json:"fingerprint"`
+}
+
+// Error is returned if a passphrase can't be generated.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// New creates a new Server.
+func New(options ...Option) (*Server, error) {
+	// Create server with default settings.
+	s := &Server{
+		limiter: newLimiter(DefaultRate, DefaultBurst),
+	}
+
+	// Apply supplied options.
+	for _, option := range options {
+		if err := option(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if !s.allow(w, r) {
+		return
+	}
+
+	var (
+		req Request
+		err error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		req, err = parseQuery(r)
+	case http.MethodPost:
+		err = json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeJSON(w, http.StatusMethodNotAllowed, Error{"method not allowed"})
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, Error{"invalid parameters"})
+		return
+	}
+
+	res, err := service.New().GeneratePassphrase(r.Context(), &req)
+	switch {
+	case err == nil:
+	case errors.Is(err, diceware.ErrInvalidWordCount):
+		writeJSON(w, http.StatusBadRequest, Error{err.Error()})
+		return
+	case errors.Is(err, diceware.ErrValidationFailed):
+		writeJSON(w, http.StatusUnprocessableEntity, Error{err.Error()})
+		return
+	default:
+		writeJSON(w, http.StatusInternalServerError, Error{"passphrase generation failed"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response{
+		Passphrase:  res.Passphrase,
+		Words:       res.Words,
+		Entropy:     res.Entropy,
+		List:        List,
+		Fingerprint: diceware.Diceware8k.Fingerprint(),
+	})
+}
+
+// parseQuery reads the Request from the query parameters.
+func parseQuery(r *http.Request) (Request, error) {
+	var req Request
+	query := r.URL.Query()
+	if v := query.Get("words"); v != "" {
+		words, err := strconv.Atoi(v)
+		if err != nil {
+			return req, err
+		}
+		req.Words = &words
+	}
+	for key, dst := range map[string]**bool{
+		"extra":    &req.Extra,
+		"checksum": &req.Checksum,
+		"validate": &req.Validate,
+	} {
+		if v := query.Get(key); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return req, err
+			}
+			*dst = &b
+		}
+	}
+	return req, nil
+}
+
+// Limit returns an http.Handler which applies the per-client rate limit of the
+// Server to h, e.g. to serve other APIs next to the Server. The limit is shared
+// with the Server.
+func (s *Server) Limit(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.allow(w, r) {
+			h.ServeHTTP(w, r)
+		}
+	})
+}
+
+// allow reports whether the client is allowed to perform the request. If not,
+// it responds with an error.
+func (s *Server) allow(w http.ResponseWriter, r *http.Request) bool {
+	if !s.limiter.allow(clientAddr(r)) {
+		w.Header().Set("Cache-Control", "no-store")
+		writeJSON(w, http.StatusTooManyRequests, Error{"rate limit exceeded"})
+		return false
+	}
+	return true
+}
+
+// clientAddr returns the address used to identify the client for rate
+// limiting.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
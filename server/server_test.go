@@ -0,0 +1,135 @@
+package server_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+	"github.com/lukasmalkmus/diceware/server"
+)
+
+func TestServer(t *testing.T) {
+	s, err := server.New()
+	ok(t, err)
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+		status int
+		words  int
+	}{
+		{http.MethodGet, "/", "", http.StatusOK, diceware.DefaultWords},
+		{http.MethodGet, "/?words=8&extra=true", "", http.StatusOK, 8},
+		{http.MethodGet, "/?words=8&checksum=1", "", http.StatusOK, 9},
+		{http.MethodGet, "/?words=3&validate=false", "", http.StatusOK, 3},
+		{http.MethodGet, "/?words=3", "", http.StatusUnprocessableEntity, 0},
+		{http.MethodGet, "/?words=0", "", http.StatusBadRequest, 0},
+		{http.MethodGet, "/?words=many", "", http.StatusBadRequest, 0},
+		{http.MethodGet, "/?words=64&validate=0", "", http.StatusOK, server.MaxWords},
+		{http.MethodGet, "/?words=500000000", "", http.StatusBadRequest, 0},
+		{http.MethodPost, "/", `{"words":65}`, http.StatusBadRequest, 0},
+		{http.MethodPost, "/", `{"words":7,"extra":true` + strings.Repeat(" ", 2048) + `}`, http.StatusBadRequest, 0},
+		{http.MethodPost, "/", `{"words":7,"extra":true}`, http.StatusOK, 7},
+		{http.MethodPost, "/", `{"words":`, http.StatusBadRequest, 0},
+		{http.MethodDelete, "/", "", http.StatusMethodNotAllowed, 0},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		r.RemoteAddr = fmt.Sprintf("192.0.2.%d:1234", len(tt.target))
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		equals(t, tt.status, w.Code)
+		equals(t, "no-store", w.Header().Get("Cache-Control"))
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var res server.Response
+		ok(t, json.NewDecoder(w.Body).Decode(&res))
+		equals(t, tt.words, len(res.Words))
+		equals(t, strings.Join(res.Words, ""), res.Passphrase)
+		equals(t, server.List, res.List)
+		equals(t, diceware.Diceware8kFingerprint, res.Fingerprint)
+		assert(t, res.Entropy > 0, "Expected entropy to be reported.")
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	_, err := server.New(server.RateLimit(0, 1))
+	equals(t, server.ErrInvalidRateLimit, err)
+
+	s, err := server.New(server.RateLimit(0.001, 2))
+	ok(t, err)
+
+	for i, status := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, r)
+		assert(t, status == w.Code, "Request %d: expected status %d, got %d", i, status, w.Code)
+	}
+
+	// Other clients are not affected.
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "198.51.100.1:1234"
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	equals(t, http.StatusOK, w.Code)
+}
+
+func TestServer_Limit(t *testing.T) {
+	s, err := server.New(server.RateLimit(0.001, 1))
+	ok(t, err)
+	h := s.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	// The limit is shared between the Server and the limited handler.
+	for i, tt := range []struct {
+		h      http.Handler
+		status int
+	}{
+		{h, http.StatusNoContent},
+		{h, http.StatusTooManyRequests},
+		{s, http.StatusTooManyRequests},
+	} {
+		w := httptest.NewRecorder()
+		tt.h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert(t, tt.status == w.Code, "Request %d: expected status %d, got %d", i, tt.status, w.Code)
+	}
+}
+
+// assert fails the test if the condition is false.
+func assert(tb testing.TB, condition bool, msg string, v ...interface{}) {
+	if !condition {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: "+msg+"\033[39m\n\n", append([]interface{}{filepath.Base(file), line}, v...)...)
+		tb.FailNow()
+	}
+}
+
+// ok fails the test if an err is not nil.
+func ok(tb testing.TB, err error) {
+	if err != nil {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: unexpected error: %s\033[39m\n\n", filepath.Base(file), line, err.Error())
+		tb.FailNow()
+	}
+}
+
+// equals fails the test if exp is not equal to act.
+func equals(tb testing.TB, exp, act interface{}) {
+	if !reflect.DeepEqual(exp, act) {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d:\n\n\texp: %#v\n\n\tgot: %#v\033[39m\n\n", filepath.Base(file), line, exp, act)
+		tb.FailNow()
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+// The paths of the methods served by the HTTP transport.
+const (
+	PathGeneratePassphrase = "/diceware.Service/GeneratePassphrase"
+	PathValidatePassphrase = "/diceware.Service/ValidatePassphrase"
+	PathEstimateStrength   = "/diceware.Service/EstimateStrength"
+)
+
+// Error codes used by the HTTP transport.
+const (
+	CodeInvalidWordCount = "invalid_word_count"
+	CodeValidationFailed = "validation_failed"
+	CodeInvalidRequest   = "invalid_request"
+	CodeInternal         = "internal"
+)
+
+// maxBodySize is the largest size of a request body in bytes.
+const maxBodySize = 1 << 16
+
+// codes maps errors of the diceware package to error codes and back.
+var codes = map[string]error{
+	CodeInvalidWordCount: diceware.ErrInvalidWordCount,
+	CodeValidationFailed: diceware.ErrValidationFailed,
+}
+
+// An Error is an error returned by a remote Service which isn't an error of
+// the diceware package.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("service: %s: %s", e.Code, e.Message)
+}
+
+// NewHandler returns an http.Handler which serves the given Service as JSON
+// over HTTP. Every method is served on its own path and accepts the JSON
+// encoded request via POST.
+func NewHandler(svc Service) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(PathGeneratePassphrase, handle(func(ctx context.Context, dec *json.Decoder) (interface{}, error) {
+		var req GenerateRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, &Error{CodeInvalidRequest, err.Error()}
+		}
+		return svc.GeneratePassphrase(ctx, &req)
+	}))
+	mux.Handle(PathValidatePassphrase, handle(func(ctx context.Context, dec *json.Decoder) (interface{}, error) {
+		var req ValidateRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, &Error{CodeInvalidRequest, err.Error()}
+		}
+		return svc.ValidatePassphrase(ctx, &req)
+	}))
+	mux.Handle(PathEstimateStrength, handle(func(ctx context.Context, dec *json.Decoder) (interface{}, error) {
+		var req EstimateRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, &Error{CodeInvalidRequest, err.Error()}
+		}
+		return svc.EstimateStrength(ctx, &req)
+	}))
+	return mux
+}
+
+type method func(ctx context.Context, dec *json.Decoder) (interface{}, error)
+
+func handle(m method) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			json.NewEncoder(w).Encode(&Error{CodeInvalidRequest, "method not allowed"})
+			return
+		}
+
+		res, err := m(r.Context(), json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)))
+		if err != nil {
+			e, status := toError(err)
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(e)
+			return
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+}
+
+// toError converts an error into an Error and the matching HTTP status code.
+func toError(err error) (*Error, int) {
+	for code, e := range codes {
+		if errors.Is(err, e) {
+			return &Error{code, err.Error()}, http.StatusBadRequest
+		}
+	}
+	if e, ok := err.(*Error); ok {
+		if e.Code == CodeInvalidRequest {
+			return e, http.StatusBadRequest
+		}
+		return e, http.StatusInternalServerError
+	}
+	return &Error{CodeInternal, "internal error"}, http.StatusInternalServerError
+}
+
+// client implements the Service by calling a remote Service over HTTP.
+type client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient returns a Service which calls the Service served by NewHandler at
+// the given base URL. If httpClient is nil, http.DefaultClient is used. Errors
+// of the diceware package are returned as such, all other errors returned by
+// the remote Service are of type *Error.
+func NewClient(url string, httpClient *http.Client) Service {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: httpClient,
+	}
+}
+
+// GeneratePassphrase implements the Service interface.
+func (c *client) GeneratePassphrase(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	var res GenerateResponse
+	if err := c.call(ctx, PathGeneratePassphrase, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// ValidatePassphrase implements the Service interface.
+func (c *client) ValidatePassphrase(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	var res ValidateResponse
+	if err := c.call(ctx, PathValidatePassphrase, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// EstimateStrength implements the Service interface.
+func (c *client) EstimateStrength(ctx context.Context, req *EstimateRequest) (*EstimateResponse, error) {
+	var res EstimateResponse
+	if err := c.call(ctx, PathEstimateStrength, req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (c *client) call(ctx context.Context, path string, req, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r, err := http.NewRequest(http.MethodPost, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(r.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e Error
+		if err := json.NewDecoder(resp.Body).Decode(&e); err != nil || e.Code == "" {
+			return &Error{CodeInternal, resp.Status}
+		}
+		if err, ok := codes[e.Code]; ok {
+			return err
+		}
+		return &e
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
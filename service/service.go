@@ -0,0 +1,194 @@
+/*
+Package service defines a typed service for the generation, validation and
+strength estimation of diceware passphrases. It comes with a local
+implementation as well as a JSON over HTTP transport and client, so services
+can call a central generator instead of embedding the word list.
+*/
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+const (
+	// MaxWords is the largest amount of words of a generated passphrase. It
+	// prevents single requests from allocating large amounts of memory.
+	MaxWords = 64
+
+	// maxAttempts is the amount of passphrases generated until one meets the
+	// length rules of the validation.
+	maxAttempts = 10
+)
+
+// Service is the diceware passphrase service.
+type Service interface {
+	// GeneratePassphrase generates a new passphrase.
+	GeneratePassphrase(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error)
+
+	// ValidatePassphrase checks a typed passphrase against the word list.
+	ValidatePassphrase(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error)
+
+	// EstimateStrength estimates the strength of a passphrase.
+	EstimateStrength(ctx context.Context, req *EstimateRequest) (*EstimateResponse, error)
+}
+
+// GenerateRequest holds the parameters of a passphrase generation. They
+// mirror the diceware Options. Unset parameters fall back to the defaults of
+// the diceware package.
+type GenerateRequest struct {
+	Words    *int  `json:"words,omitempty"`
+	Extra    *bool `json:"extra,omitempty"`
+	Checksum *bool `json:"checksum,omitempty"`
+	Validate *bool `json:"validate,omitempty"`
+}
+
+// Config returns the configuration of the passphrase generation: the defaults
+// of the diceware package, overridden by the set parameters. A
+// *diceware.WordCountError is returned if more than MaxWords words are
+// requested.
+func (req GenerateRequest) Config() (diceware.Config, error) {
+	cfg := diceware.DefaultConfig()
+	if req.Words != nil {
+		if *req.Words > MaxWords {
+			return cfg, &diceware.WordCountError{Got: *req.Words, Min: diceware.MinWords, Max: MaxWords}
+		}
+		cfg.Words = *req.Words
+	}
+	if req.Extra != nil {
+		cfg.Extra = *req.Extra
+	}
+	if req.Checksum != nil {
+		cfg.Checksum = *req.Checksum
+	}
+	if req.Validate != nil {
+		cfg.Validate = *req.Validate
+	}
+	return cfg, nil
+}
+
+// GenerateResponse is the result of a passphrase generation.
+type GenerateResponse struct {
+	Passphrase string   `json:"passphrase"`
+	Words      []string `json:"words"`
+	Entropy    float64  `json:"entropy"`
+}
+
+// ValidateRequest holds a typed passphrase which should be validated.
+type ValidateRequest struct {
+	Passphrase string `json:"passphrase"`
+
+	// Checksum specifies whaether the last word is a checksum word.
+	Checksum bool `json:"checksum,omitempty"`
+}
+
+// ValidateResponse is the result of a passphrase validation.
+type ValidateResponse struct {
+	Valid bool `json:"valid"`
+
+	// Words are the normalized and corrected words of the passphrase.
+	Words []string `json:"words,omitempty"`
+
+	// Corrections are the corrections which were applied to the input.
+	Corrections []diceware.Correction `json:"corrections,omitempty"`
+
+	// Reason explains why the passphrase is invalid.
+	Reason string `json:"reason,omitempty"`
+}
+
+// EstimateRequest holds a passphrase whose strength should be estimated.
+type EstimateRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// EstimateResponse is the result of a strength estimation.
+type EstimateResponse struct {
+	// Guesses is the estimated amount of guesses needed to crack the
+	// passphrase.
+	Guesses float64 `json:"guesses"`
+
+	// Entropy is the estimated entropy in bits.
+	Entropy float64 `json:"entropy"`
+
+	// Feedback contains suggestions on how to improve the passphrase.
+	Feedback []string `json:"feedback,omitempty"`
+}
+
+// local implements the Service using the diceware package.
+type local struct{}
+
+// New returns a Service which is backed by the diceware package.
+func New() Service {
+	return local{}
+}
+
+// GeneratePassphrase implements the Service interface. Passphrases which fail
+// validation only because they are too short are generated again, since another
+// passphrase of the same configuration meets the rules.
+func (local) GeneratePassphrase(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	cfg, err := req.Config()
+	if err != nil {
+		return nil, err
+	}
+	p, err := diceware.NewPassphrase(cfg.Options()...)
+	for i := 1; i < maxAttempts && lengthOnly(err); i++ {
+		p, err = diceware.NewPassphrase(cfg.Options()...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &GenerateResponse{
+		Passphrase: p.String(),
+		Words:      p.Words(),
+		Entropy:    p.Entropy(),
+	}, nil
+}
+
+// lengthOnly reports whether the error is a validation error which only violates
+// length rules.
+func lengthOnly(err error) bool {
+	var vErr *diceware.ValidationError
+	if !errors.As(err, &vErr) {
+		return false
+	}
+	for _, v := range vErr.Rules {
+		if v.Rule != diceware.RuleMinLength && v.Rule != diceware.RuleMaxLength {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePassphrase implements the Service interface.
+func (local) ValidatePassphrase(ctx context.Context, req *ValidateRequest) (*ValidateResponse, error) {
+	words, corrections, err := diceware.Correct(req.Passphrase)
+	if err != nil {
+		return &ValidateResponse{Reason: err.Error()}, nil
+	}
+	res := &ValidateResponse{Words: words, Corrections: corrections}
+	if len(words) == 0 {
+		res.Reason = diceware.ErrInvalidWordCount.Error()
+		return res, nil
+	}
+	if req.Checksum {
+		if err := diceware.VerifyChecksum(words); err != nil {
+			res.Reason = err.Error()
+			return res, nil
+		}
+	}
+	res.Valid = true
+	return res, nil
+}
+
+// EstimateStrength implements the Service interface. The passphrase can be
+// any password, see diceware.Estimate for details.
+func (local) EstimateStrength(ctx context.Context, req *EstimateRequest) (*EstimateResponse, error) {
+	s := diceware.Estimate(req.Passphrase)
+	return &EstimateResponse{
+		Guesses:  s.Guesses,
+		Entropy:  s.Entropy,
+		Feedback: s.Feedback,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+	"github.com/lukasmalkmus/diceware/service"
+)
+
+// services returns the local Service and a client of the same Service served
+// over HTTP. The returned function stops the HTTP server.
+func services() (map[string]service.Service, func()) {
+	srv := httptest.NewServer(service.NewHandler(service.New()))
+	return map[string]service.Service{
+		"local":  service.New(),
+		"remote": service.NewClient(srv.URL, srv.Client()),
+	}, srv.Close
+}
+
+func TestService_GeneratePassphrase(t *testing.T) {
+	svcs, stop := services()
+	defer stop()
+
+	words, extra, validate := 4, true, false
+	for name, svc := range svcs {
+		res, err := svc.GeneratePassphrase(context.Background(), &service.GenerateRequest{})
+		ok(t, err)
+		equals(t, diceware.DefaultWords, len(res.Words))
+		equals(t, strings.Join(res.Words, ""), res.Passphrase)
+
+		res, err = svc.GeneratePassphrase(context.Background(), &service.GenerateRequest{
+			Words:    &words,
+			Extra:    &extra,
+			Validate: &validate,
+		})
+		ok(t, err)
+		equals(t, words, len(res.Words))
+		assert(t, res.Entropy > float64(words*diceware.BitsPerWord), "%s: expected extra to add entropy.", name)
+
+		_, err = svc.GeneratePassphrase(context.Background(), &service.GenerateRequest{Words: &words})
+		assert(t, errors.Is(err, diceware.ErrValidationFailed), "%s: expected validation to fail, got %v.", name, err)
+
+		tooMany := service.MaxWords + 1
+		_, err = svc.GeneratePassphrase(context.Background(), &service.GenerateRequest{Words: &tooMany})
+		assert(t, errors.Is(err, diceware.ErrInvalidWordCount), "%s: expected too many words, got %v.", name, err)
+	}
+}
+
+func TestGenerateRequest_Config(t *testing.T) {
+	cfg, err := service.GenerateRequest{}.Config()
+	ok(t, err)
+	equals(t, diceware.DefaultConfig(), cfg)
+
+	words, extra, checksum, validate := 8, true, true, false
+	cfg, err = service.GenerateRequest{Words: &words, Extra: &extra, Checksum: &checksum, Validate: &validate}.Config()
+	ok(t, err)
+	expected := diceware.DefaultConfig()
+	expected.Words, expected.Extra, expected.Checksum, expected.Validate = words, extra, checksum, validate
+	equals(t, expected, cfg)
+}
+
+func TestService_ValidatePassphrase(t *testing.T) {
+	svcs, stop := services()
+	defer stop()
+
+	tests := []struct {
+		req   service.ValidateRequest
+		valid bool
+		words []string
+	}{
+		{service.ValidateRequest{Passphrase: "lofty geese"}, true, []string{"lofty", "geese"}},
+		{service.ValidateRequest{Passphrase: "Lotfy geese"}, true, []string{"lofty", "geese"}},
+		{service.ValidateRequest{Passphrase: "lofty ñandú"}, false, nil},
+		{service.ValidateRequest{Passphrase: ""}, false, nil},
+		{service.ValidateRequest{Passphrase: "lofty geese", Checksum: true}, false, []string{"lofty", "geese"}},
+	}
+
+	for _, svc := range svcs {
+		for _, tt := range tests {
+			res, err := svc.ValidatePassphrase(context.Background(), &tt.req)
+			ok(t, err)
+			equals(t, tt.valid, res.Valid)
+			equals(t, tt.words, res.Words)
+			assert(t, res.Valid == (res.Reason == ""), "Expected reason for invalid passphrase %q.", tt.req.Passphrase)
+		}
+	}
+}
+
+func TestService_EstimateStrength(t *testing.T) {
+	svcs, stop := services()
+	defer stop()
+
+	for _, svc := range svcs {
+		res, err := svc.EstimateStrength(context.Background(), &service.EstimateRequest{Passphrase: "lofty geese borne"})
+		ok(t, err)
+		s := diceware.Estimate("lofty geese borne")
+		equals(t, s.Entropy, res.Entropy)
+		equals(t, s.Feedback, res.Feedback)
+	}
+}
+
+// assert fails the test if the condition is false.
+func assert(tb testing.TB, condition bool, msg string, v ...interface{}) {
+	if !condition {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: "+msg+"\033[39m\n\n", append([]interface{}{filepath.Base(file), line}, v...)...)
+		tb.FailNow()
+	}
+}
+
+// ok fails the test if an err is not nil.
+func ok(tb testing.TB, err error) {
+	if err != nil {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d: unexpected error: %s\033[39m\n\n", filepath.Base(file), line, err.Error())
+		tb.FailNow()
+	}
+}
+
+// equals fails the test if exp is not equal to act.
+func equals(tb testing.TB, exp, act interface{}) {
+	if !reflect.DeepEqual(exp, act) {
+		_, file, line, _ := runtime.Caller(1)
+		fmt.Printf("\033[31m%s:%d:\n\n\texp: %#v\n\n\tgot: %#v\033[39m\n\n", filepath.Base(file), line, exp, act)
+		tb.FailNow()
+	}
+}
@@ -0,0 +1,71 @@
+package diceware
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// WriteTextSheet writes the word list as plain text to w. Every word is
+// preceded by its dice roll (see WordList for the dice roll notation). The
+// words are arranged in the given amount of columns, which are read from top
+// to bottom.
+func WriteTextSheet(w io.Writer, l *WordList, columns int) error {
+	if columns < 1 {
+		columns = 1
+	}
+
+	// Calculate the width of a single entry.
+	width := 0
+	for i := 0; i < l.Len(); i++ {
+		if n := len(l.diceCode(i)) + 1 + len(l.Word(i)); n > width {
+			width = n
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	rows := (l.Len() + columns - 1) / columns
+	for row := 0; row < rows; row++ {
+		var line []string
+		for col := 0; col < columns; col++ {
+			i := col*rows + row
+			if i >= l.Len() {
+				break
+			}
+			line = append(line, fmt.Sprintf("%-*s", width, l.diceCode(i)+" "+l.Word(i)))
+		}
+		fmt.Fprintln(bw, strings.TrimRight(strings.Join(line, "  "), " "))
+	}
+	return bw.Flush()
+}
+
+// WriteHTMLSheet writes the word list as self-contained, printable HTML page
+// to w. Every word is preceded by its dice roll (see WordList for the dice roll
+// notation).
+func WriteHTMLSheet(w io.Writer, l *WordList) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%[1]s</title>
+<style>
+body { font-family: sans-serif; font-size: 9pt; margin: 1cm; }
+ol { column-count: 6; column-gap: 1em; list-style: none; margin: 0; padding: 0; }
+li { break-inside: avoid; white-space: nowrap; }
+code { color: #555; margin-right: 0.5em; }
+@page { margin: 1cm; }
+</style>
+</head>
+<body>
+<h1>%[1]s</h1>
+<ol>
+`, html.EscapeString(l.Name()))
+	for i := 0; i < l.Len(); i++ {
+		fmt.Fprintf(bw, "<li><code>%s</code>%s</li>\n", l.diceCode(i), html.EscapeString(l.Word(i)))
+	}
+	fmt.Fprint(bw, "</ol>\n</body>\n</html>\n")
+	return bw.Flush()
+}
@@ -0,0 +1,27 @@
+package diceware_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestWriteTextSheet(t *testing.T) {
+	var buf bytes.Buffer
+	ok(t, diceware.WriteTextSheet(&buf, diceware.Diceware8k, 4))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	equals(t, diceware.Diceware8k.Len()/4, len(lines))
+	equals(t, []string{"H111111", "a", "H311111", "ej", "T111111", "macho", "T311111", "snip"}, strings.Fields(lines[0]))
+	equals(t, []string{"H244444", "eire", "H444444", "mach", "T244444", "sniff", "T444444", "@"}, strings.Fields(lines[len(lines)-1]))
+}
+
+func TestWriteHTMLSheet(t *testing.T) {
+	var buf bytes.Buffer
+	ok(t, diceware.WriteHTMLSheet(&buf, diceware.Diceware8k))
+
+	equals(t, diceware.Diceware8k.Len(), strings.Count(buf.String(), "<li>"))
+	assert(t, strings.Contains(buf.String(), "<li><code>H111112</code>a&amp;p</li>"), "Expected words to be escaped.")
+}
@@ -0,0 +1,207 @@
+package diceware
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	// DefaultMinEditDistance is the default value for the minimum edit distance
+	// between two words of a passphrase. It is disabled by default.
+	DefaultMinEditDistance = 0
+
+	// DefaultDistinctSounds is the default value for preventing words which
+	// sound alike.
+	DefaultDistinctSounds = false
+)
+
+// ErrInvalidEditDistance is raised when the specified edit distance is
+// negative.
+var ErrInvalidEditDistance = errors.New("diceware: edit distance is invalid")
+
+// MinEditDistance is an Option that requires every two words of a passphrase
+// to differ by at least the given edit distance (Levenshtein distance). This
+// prevents words like "a2" and "a3" which are easily confused. The entropy is
+// reduced accordingly, see Passphrase.Entropy.
+//
+// Large distances exclude many words: with a distance of 3, a short word of
+// the diceware8k list excludes up to 1721 words. A *WordCountError is
+// returned if the amount of words can't be guaranteed.
+func MinEditDistance(distance int) Option {
+	return func(p *Passphrase) error { return p.setMinEditDistance(distance) }
+}
+func (p *Passphrase) setMinEditDistance(distance int) error {
+	if distance < 0 {
+		return ErrInvalidEditDistance
+	}
+	p.minEditDistance = distance
+	return nil
+}
+
+// DistinctSounds is an Option that specifies whaether words which sound alike
+// (have the same Soundex code) are prevented within a passphrase. The entropy is
+// reduced accordingly, see Passphrase.Entropy.
+func DistinctSounds(distinct bool) Option {
+	return func(p *Passphrase) error { return p.setDistinctSounds(distinct) }
+}
+func (p *Passphrase) setDistinctSounds(distinct bool) error {
+	p.distinctSounds = distinct
+	return nil
+}
+
+// similarity defines when two words are considered similar.
+type similarity struct {
+	list            *WordList
+	minEditDistance int
+	distinctSounds  bool
+}
+
+// similar reports whether the two words are considered similar.
+func (s similarity) similar(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if s.distinctSounds && soundex(a) == soundex(b) {
+		return true
+	}
+	return s.minEditDistance > 1 && editDistance(a, b, s.minEditDistance) < s.minEditDistance
+}
+
+var (
+	similarMu    sync.Mutex
+	similarCache = make(map[similarity]int)
+)
+
+// maxSimilar returns the largest amount of words of the list which are similar
+// to a single word of the list, including the word itself. The result is
+// cached, as all pairs of words have to be compared.
+func (s similarity) maxSimilar() int {
+	similarMu.Lock()
+	defer similarMu.Unlock()
+
+	if n, ok := similarCache[s]; ok {
+		return n
+	}
+
+	words := s.list.load()
+	counts := make([]int, len(words))
+	codes := make([]string, len(words))
+	for i, word := range words {
+		counts[i] = 1
+		codes[i] = soundex(word)
+	}
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			a, b := words[i], words[j]
+			if (s.distinctSounds && codes[i] == codes[j]) ||
+				(s.minEditDistance > 1 && editDistance(a, b, s.minEditDistance) < s.minEditDistance) {
+				counts[i]++
+				counts[j]++
+			}
+		}
+	}
+
+	max := 0
+	for _, n := range counts {
+		if n > max {
+			max = n
+		}
+	}
+	similarCache[s] = max
+	return max
+}
+
+// editDistance returns the Levenshtein distance between a and b. The
+// calculation stops early once the distance reaches limit, in which case limit
+// is returned.
+func editDistance(a, b string, limit int) int {
+	if d := len(a) - len(b); d >= limit || -d >= limit {
+		return limit
+	}
+
+	// Avoid allocations for the short words of the list.
+	var buf [2][16]int
+	prev, cur := buf[0][:], buf[1][:]
+	if len(b) >= len(buf[0]) {
+		prev, cur = make([]int, len(b)+1), make([]int, len(b)+1)
+	}
+
+	for j := 0; j <= len(b); j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if cur[j] < rowMin {
+				rowMin = cur[j]
+			}
+		}
+		if rowMin >= limit {
+			return limit
+		}
+		prev, cur = cur, prev
+	}
+	if prev[len(b)] > limit {
+		return limit
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// soundexCodes maps letters to their Soundex digit. Vowels and "y" map to 0,
+// "h" and "w" are ignored.
+var soundexCodes = [26]byte{
+	'0', '1', '2', '3', '0', '1', '2', 0, '0', '2', '2', '4', '5',
+	'5', '0', '1', '2', '6', '2', '3', '0', '1', 0, '2', '0', '2',
+}
+
+// soundex returns the American Soundex code of the word. Only letters are taken
+// into account. Words without any letter are returned unchanged, so they only
+// sound like themselves.
+func soundex(word string) string {
+	code := make([]byte, 0, 4)
+	var last byte
+	for i := 0; i < len(word) && len(code) < 4; i++ {
+		c := word[i] | 0x20
+		if c < 'a' || c > 'z' {
+			continue
+		}
+		d := soundexCodes[c-'a']
+		if len(code) == 0 {
+			code = append(code, c-0x20)
+			last = d
+			continue
+		}
+		switch {
+		case d == 0:
+			// "h" and "w" don't separate letters with the same code.
+		case d == '0':
+			last = d
+		case d != last:
+			code = append(code, d)
+			last = d
+		}
+	}
+	if len(code) == 0 {
+		return word
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
@@ -0,0 +1,132 @@
+package diceware_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPassphrase_Similarity(t *testing.T) {
+	tests := []struct {
+		options []diceware.Option
+		words   int
+		similar func(a, b string) bool
+	}{
+		{[]diceware.Option{diceware.MinEditDistance(2)}, 20, func(a, b string) bool { return distance(a, b) < 2 }},
+		{[]diceware.Option{diceware.MinEditDistance(3)}, 4, func(a, b string) bool { return distance(a, b) < 3 }},
+		{[]diceware.Option{diceware.DistinctSounds(true)}, 20, func(a, b string) bool { return soundex(a) == soundex(b) }},
+	}
+
+	for _, tt := range tests {
+		phrase, err := diceware.NewPassphrase(append(tt.options, diceware.Words(tt.words), diceware.Validate(false))...)
+		ok(t, err)
+		words := strings.Fields(phrase.Humanize())
+		for i := range words {
+			for j := i + 1; j < len(words); j++ {
+				assert(t, !tt.similar(words[i], words[j]), "Words %q and %q are similar.", words[i], words[j])
+			}
+		}
+
+		// The entropy must be lower than without the restriction but higher
+		// than with one word less.
+		entropy := phrase.Entropy()
+		assert(t, entropy < float64(tt.words*diceware.BitsPerWord), "Expected reduced entropy, got %v.", entropy)
+		assert(t, entropy > float64((tt.words-1)*diceware.BitsPerWord), "Expected entropy of more than %d words, got %v.", tt.words-1, entropy)
+	}
+
+	_, err := diceware.NewPassphrase(diceware.MinEditDistance(-1))
+	equals(t, diceware.ErrInvalidEditDistance, err)
+
+	_, err = diceware.NewPassphrase(diceware.MinEditDistance(3), diceware.Words(6))
+	assert(t, errors.Is(err, diceware.ErrInvalidWordCount), "Expected invalid word count, got %v.", err)
+}
+
+func TestSoundex(t *testing.T) {
+	// Known codes of the American Soundex algorithm, so the reference
+	// implementation used above can be trusted.
+	tests := []struct {
+		words []string
+		code  string
+	}{
+		{[]string{"robert", "rupert", "robbert"}, "R163"},
+		{[]string{"ashcraft", "ashcroft"}, "A261"},
+		{[]string{"tymczak"}, "T522"},
+		{[]string{"pfister"}, "P236"},
+		{[]string{"honeyman"}, "H555"},
+		{[]string{"lee", "lea", "leah"}, "L000"},
+		{[]string{"smith", "smyth", "smithe"}, "S530"},
+	}
+
+	for _, tt := range tests {
+		for _, word := range tt.words {
+			equals(t, tt.code, soundex(word))
+		}
+	}
+}
+
+// soundex returns the American Soundex code of the word, ignoring characters
+// other than letters. Words without letters are returned unchanged.
+func soundex(word string) string {
+	groups := map[rune]byte{}
+	for i, letters := range []string{"aeiouy", "bfpv", "cgjkqsxz", "dt", "l", "mn", "r"} {
+		for _, c := range letters {
+			groups[c] = byte('0' + i)
+		}
+	}
+
+	letters := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(word))
+	if letters == "" {
+		return word
+	}
+
+	code := []byte{byte(unicode.ToUpper(rune(letters[0])))}
+	last := groups[rune(letters[0])]
+	for _, c := range letters[1:] {
+		g, ok := groups[c]
+		if !ok {
+			// "h" and "w" don't separate letters of the same group.
+			continue
+		}
+		if g != '0' && g != last {
+			code = append(code, g)
+		}
+		last = g
+	}
+	return (string(code) + "000")[:4]
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = d[i-1][j] + 1
+			if v := d[i][j-1] + 1; v < d[i][j] {
+				d[i][j] = v
+			}
+			if v := d[i-1][j-1] + cost; v < d[i][j] {
+				d[i][j] = v
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,90 @@
+package diceware
+
+import "strings"
+
+// An Alphabet maps characters to the words used to spell them aloud. Custom
+// alphabets can be used to spell passphrases in other languages.
+type Alphabet map[rune]string
+
+// NATO is the NATO phonetic alphabet, extended by the english names of digits
+// and of the symbols found in the word list and the extras.
+var NATO = Alphabet{
+	'a': "Alfa", 'b': "Bravo", 'c': "Charlie", 'd': "Delta", 'e': "Echo",
+	'f': "Foxtrot", 'g': "Golf", 'h': "Hotel", 'i': "India", 'j': "Juliett",
+	'k': "Kilo", 'l': "Lima", 'm': "Mike", 'n': "November", 'o': "Oscar",
+	'p': "Papa", 'q': "Quebec", 'r': "Romeo", 's': "Sierra", 't': "Tango",
+	'u': "Uniform", 'v': "Victor", 'w': "Whiskey", 'x': "X-ray", 'y': "Yankee",
+	'z': "Zulu",
+
+	'0': "zero", '1': "one", '2': "two", '3': "three", '4': "four",
+	'5': "five", '6': "six", '7': "seven", '8': "eight", '9': "nine",
+
+	'~': "tilde", '!': "exclamation mark", '#': "hash", '$': "dollar sign",
+	'%': "percent sign", '^': "caret", '&': "ampersand", '*': "asterisk",
+	'(': "left parenthesis", ')': "right parenthesis", '-': "dash",
+	'=': "equals sign", '+': "plus sign", '[': "left bracket",
+	']': "right bracket", '\\': "backslash", '{': "left brace",
+	'}': "right brace", ':': "colon", ';': "semicolon", '"': "double quote",
+	'\'': "apostrophe", '<': "less-than sign", '>': "greater-than sign",
+	'?': "question mark", '/': "slash", '@': "at sign", '.': "period",
+}
+
+// Spell returns the spelling of the text using the alphabet. The words of the
+// spelling are separated by whitespace. Characters which are not part of the
+// alphabet are returned as they are.
+func (a Alphabet) Spell(text string) string {
+	spelling := make([]string, 0, len(text))
+	for _, r := range text {
+		if word, ok := a[r]; ok {
+			spelling = append(spelling, word)
+		} else {
+			spelling = append(spelling, string(r))
+		}
+	}
+	return strings.Join(spelling, " ")
+}
+
+// Spoken returns the passphrase in a form which can be read aloud without
+// being misheard. Words which can be pronounced (at least three letters, a
+// vowel and a consonant) are kept as they are. All other words, like "a&p",
+// "9th", "??", "eee" or "ieee", are spelled using the alphabet, just like the
+// extra. The spoken words are separated by " / ".
+func (p Passphrase) Spoken(a Alphabet) string {
+	spoken := make([]string, len(p.words))
+	for i, word := range p.words {
+		extra := ""
+		if p.extra && i == p.extraIndex {
+			word, extra = word[:len(word)-1], word[len(word)-1:]
+		}
+
+		if pronounceable(word) {
+			spoken[i] = word
+		} else {
+			spoken[i] = a.Spell(word)
+		}
+		if extra != "" {
+			spoken[i] += " " + a.Spell(extra)
+		}
+	}
+	return strings.Join(spoken, " / ")
+}
+
+// pronounceable reports whether the word can be read aloud as it is. Words
+// which only consist of vowels or of a single repeated letter, like "aaa",
+// can't be told apart by ear, e.g. from "aaaa".
+func pronounceable(word string) bool {
+	if len(word) < 3 {
+		return false
+	}
+	vowel, consonant, repeated := false, false, true
+	for i, r := range word {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+		// "y" is both, like in "you" and "gym".
+		vowel = vowel || strings.ContainsRune("aeiouy", r)
+		consonant = consonant || !strings.ContainsRune("aeiou", r)
+		repeated = repeated && (i == 0 || word[i] == word[0])
+	}
+	return vowel && consonant && !repeated
+}
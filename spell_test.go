@@ -0,0 +1,67 @@
+package diceware_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestAlphabet_Spell(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"a&p", "Alfa ampersand Papa"},
+		{"9th", "nine Tango Hotel"},
+		{"??", "question mark question mark"},
+		{"ä", "ä"},
+	}
+
+	for _, tt := range tests {
+		equals(t, tt.expected, diceware.NATO.Spell(tt.text))
+	}
+
+	german := diceware.Alphabet{'a': "Anton", 'p': "Paula", '&': "und"}
+	equals(t, "Anton und Paula", german.Spell("a&p"))
+}
+
+func TestPassphrase_Spoken(t *testing.T) {
+	tests := []struct {
+		counter  uint32
+		expected string
+	}{
+		{0, "lofty / geese / borne / loess left brace / covet / Foxtrot Foxtrot"},
+		{9, "meek / arum / lust / curb plus sign / Yankee apostrophe Sierra / staph"},
+	}
+
+	for _, tt := range tests {
+		phrase, err := diceware.Derive(seed, "example.com", diceware.Extra(true), diceware.Counter(tt.counter))
+		ok(t, err)
+		equals(t, tt.expected, phrase.Spoken(diceware.NATO))
+	}
+
+	// Words of repeated letters or only vowels are spelled, while other words
+	// are kept.
+	spelled := map[string]string{
+		"aaa":  "Alfa Alfa Alfa",
+		"aaaa": "Alfa Alfa Alfa Alfa",
+		"eee":  "Echo Echo Echo",
+		"ieee": "India Echo Echo Echo",
+		"yyy":  "Yankee Yankee Yankee",
+		"you":  "you",
+		"gym":  "gym",
+	}
+	words := make([]string, 0, len(spelled))
+	for word := range spelled {
+		words = append(words, word)
+	}
+	l, err := diceware.ReadWordList("confusable", strings.NewReader(strings.Join(words, "\n")))
+	ok(t, err)
+	phrase, err := diceware.NewPassphrase(diceware.List(l), diceware.Words(20), diceware.Validate(false))
+	ok(t, err)
+	spoken := strings.Split(phrase.Spoken(diceware.NATO), " / ")
+	for i, word := range phrase.Words() {
+		equals(t, spelled[word], spoken[i])
+	}
+}
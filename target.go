@@ -0,0 +1,70 @@
+package diceware
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidEntropy is raised when the specified target entropy is not
+// positive.
+var ErrInvalidEntropy = errors.New("diceware: target entropy is invalid")
+
+// MinEntropy is an Option that defines the entropy in bits the passphrase must
+// at least provide. It replaces the Words Option: the smallest amount of words
+// which provides the entropy is chosen. If an extra provides the missing
+// entropy, it is added instead of another word, since it results in a shorter
+// passphrase, unless the Extra Option is set to false. The other Options, like
+// Unique, are taken into account.
+//
+// If length bounds are set (see MaxLength), the amount of words is chosen
+// within the bounds and no extra is added automatically.
+//
+// The validation still requires DefaultWords words. Targets which are reached
+// with fewer words, e.g. 70 bits, fail the validation unless the Validate
+// Option is set to false.
+func MinEntropy(bits float64) Option {
+	return func(p *Passphrase) error { return p.setMinEntropy(bits) }
+}
+func (p *Passphrase) setMinEntropy(bits float64) error {
+	if bits <= 0 || math.IsNaN(bits) || math.IsInf(bits, 0) {
+		return ErrInvalidEntropy
+	}
+	p.minEntropy = bits
+	return nil
+}
+
+// targetBits returns the entropy the words of a length-bounded passphrase must
+// at least provide.
+func (p Passphrase) targetBits() float64 {
+	if p.minEntropy > 0 {
+		return p.minEntropy
+	}
+	return float64(p.wordCount) * math.Log2(float64(p.list.Len()))
+}
+
+// resolveMinEntropy chooses the amount of words and whaether an extra is added
+// to reach the target entropy with the shortest passphrase.
+func (p *Passphrase) resolveMinEntropy() error {
+	if p.minEntropy == 0 || p.bounded() {
+		return nil
+	}
+
+	extra := p.extra
+	for k := MinWords; k <= p.list.Len(); k++ {
+		if excluded := p.excluded(); excluded > 0 && p.list.Len()-(k-1)*excluded < 1 {
+			break
+		}
+
+		p.wordCount, p.extra = k, extra
+		if p.Entropy() >= p.minEntropy {
+			return nil
+		}
+		if !extra && !p.extraSet {
+			p.extra = true
+			if p.Entropy() >= p.minEntropy {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidEntropy
+}
@@ -0,0 +1,66 @@
+package diceware_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lukasmalkmus/diceware"
+)
+
+func TestPassphrase_MinEntropy(t *testing.T) {
+	tests := []struct {
+		bits    float64
+		options []diceware.Option
+		words   int
+		extra   bool
+	}{
+		// 6 words provide 78 bits, 6 words and an extra 85.75 bits.
+		{78, nil, 6, false},
+		{80, nil, 6, true},
+		{90, nil, 7, false},
+		{90, []diceware.Option{diceware.Extra(true)}, 7, true},
+		{80, []diceware.Option{diceware.Extra(false)}, 7, false},
+		{78, []diceware.Option{diceware.Unique(true)}, 6, true},
+	}
+
+	for _, tt := range tests {
+		phrase, err := diceware.NewPassphrase(append(tt.options, diceware.MinEntropy(tt.bits), diceware.Validate(false))...)
+		ok(t, err)
+		equals(t, tt.words, len(strings.Fields(phrase.Humanize())))
+		assert(t, phrase.Entropy() >= tt.bits, "Expected entropy of at least %v bits, got %v.", tt.bits, phrase.Entropy())
+
+		// The passphrase must be equivalent to one with explicit options.
+		explicit, err := diceware.NewPassphrase(append(tt.options, diceware.Words(tt.words), diceware.Extra(tt.extra), diceware.Validate(false))...)
+		ok(t, err)
+		equals(t, explicit.Entropy(), phrase.Entropy())
+	}
+
+	// Length-bounded passphrases count the words within the bounds.
+	phrase, err := diceware.NewPassphrase(diceware.MinEntropy(80), diceware.MaxLength(24))
+	ok(t, err)
+	equals(t, 7, len(strings.Fields(phrase.Humanize())))
+	assert(t, phrase.Entropy() >= 80, "Expected entropy of at least 80 bits, got %v.", phrase.Entropy())
+	assert(t, len(phrase.String()) <= 24, "Expected at most 24 characters, got %q.", phrase.String())
+
+	// The words chosen within length bounds count for the validation and the
+	// configuration.
+	phrase, err = diceware.NewPassphrase(diceware.MinEntropy(60), diceware.MaxLength(40), diceware.Validate(false))
+	ok(t, err)
+	equals(t, 5, phrase.Len())
+	equals(t, 5, phrase.Config().Words)
+	assert(t, !phrase.Validate(), "Expected validation to fail for %d words.", phrase.Len())
+
+	// Targets below the entropy of DefaultWords words fail the validation.
+	_, err = diceware.NewPassphrase(diceware.MinEntropy(60))
+	var vErr *diceware.ValidationError
+	assert(t, errors.As(err, &vErr), "Expected validation error, got %v.", err)
+	phrase, err = diceware.NewPassphrase(diceware.MinEntropy(60), diceware.Validate(false))
+	ok(t, err)
+	assert(t, phrase.Entropy() >= 60, "Expected entropy of at least 60 bits, got %v.", phrase.Entropy())
+
+	for _, bits := range []float64{0, -1} {
+		_, err := diceware.NewPassphrase(diceware.MinEntropy(bits))
+		equals(t, diceware.ErrInvalidEntropy, err)
+	}
+}